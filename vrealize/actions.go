@@ -2,82 +2,248 @@ package vrealize
 
 import (
 	"fmt"
+	"path"
+	"sort"
+	"strings"
 )
 
 //ActionTemplate - is used to store action template
 //which is further used to make REST POST call for any action
 //for example - poweroff action, destroy action.
 type ActionTemplate struct {
-	ActionID string `json:"actionId"`
-	Data     struct {
-		Description  interface{} `json:"description"`
-		Reasons      interface{} `json:"reasons"`
-		ForceDestroy bool        `json:"ForceDestroy"`
-	} `json:"data"`
-	Description interface{} `json:"description"`
-	ResourceID  string      `json:"resourceId"`
-	Type        string      `json:"type"`
+	ActionID    string                 `json:"actionId"`
+	Data        map[string]interface{} `json:"data"`
+	Description interface{}            `json:"description"`
+	ResourceID  string                 `json:"resourceId"`
+	Type        string                 `json:"type"`
 }
 
-//GetActionTemplate - set call for read template/blueprint
-func (c *APIClient) GetActionTemplate(resourceViewsTemplate *ResourceViewsTemplate, actionURLString string) (*ActionTemplate, *ResourceViewsTemplate, error) {
-	//Fetch an action URL from given template
-	actionURL := getactionURL(resourceViewsTemplate, actionURLString)
+//Binding IDs of the deployment operations which are run by vra7_resource
+const (
+	changeLeaseOperation       = "composition.resource.action.deployment.changelease"
+	destroyDeploymentOperation = "composition.resource.action.deployment.destroy"
+)
 
-	//Raise an error if action URL not found
-	if len(actionURL) == 0 {
-		return nil, resourceViewsTemplate, fmt.Errorf("resource is not created or not found")
-	}
+//powerStates - power states a machine can be brought into
+var powerStates = []string{"on", "off", "suspended"}
 
-	actionTemplate := new(ActionTemplate)
+//machinePowerActions - binding IDs of the machine operations by the power state they lead to
+var machinePowerActions = map[string]string{
+	"on":        "Infrastructure.Machine.Action.PowerOn",
+	"off":       "Infrastructure.Machine.Action.PowerOff",
+	"suspended": "Infrastructure.Machine.Action.Suspend",
+}
+
+//PerformAction - To submit a filled action template to the action URL of a resource
+//and return the ID of the request which vRA created for the action
+func (c *APIClient) PerformAction(actionTemplate *ActionTemplate, actionURL string) (string, error) {
 	apiError := new(APIError)
 
-	//Set a REST call to perform an action on resource
-	_, err := c.HTTPClient.New().Get(actionURL).Receive(actionTemplate, apiError)
+	//Set a REST call to submit the action request with the action template as a data
+	resp, err := c.HTTPClient.New().Post(actionURL).
+		BodyJSON(actionTemplate).Receive(nil, apiError)
 
 	if err != nil {
-		return nil, resourceViewsTemplate, err
+		return "", err
 	}
 
 	if !apiError.isEmpty() {
-		return nil, resourceViewsTemplate, apiError
+		return "", apiError
+	}
+
+	if resp.StatusCode != 201 {
+		return "", fmt.Errorf("action request failed with status %s", resp.Status)
 	}
 
-	return actionTemplate, resourceViewsTemplate, nil
+	//The created request is referred by the location header
+	return path.Base(resp.Header.Get("Location")), nil
 }
 
-//getactionURL - Read action URL from provided template of resource item
-func getactionURL(template *ResourceViewsTemplate, relationVal string) (templateactionURL string) {
-	var actionURL string
-	l := len(template.Content)
-	//Loop to iterate over the action URLs
-	for i := 0; i < l; i++ {
-		lengthLinks := len(template.Content[i].Links)
-		for j := 0; j < lengthLinks; j++ {
-			//If template action URL matches with given URL then store it in actionURL var
-			if template.Content[i].Links[j].Rel == relationVal {
-				actionURL = template.Content[i].Links[j].Href
-			}
+//ResourceOperation - is used to store an operation which the current user is
+//entitled to run on a resource
+type ResourceOperation struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	Type           string `json:"type"`
+	BindingID      string `json:"bindingId"`
+	ProviderTypeID string `json:"providerTypeId"`
+}
+
+//resourceOperationList - is used to store one page of resource operations
+type resourceOperationList struct {
+	Content  []ResourceOperation `json:"content"`
+	Metadata Metadata            `json:"metadata"`
+}
+
+//ComponentAction - is used to store the action URLs of an operation on one resource
+//of a deployment
+type ComponentAction struct {
+	ResourceID    string
+	ComponentName string
+	Operation     ResourceOperation
+	TemplateURL   string
+	RequestURL    string
+}
+
+//GetResourceOperations - To read all operations available on a resource
+func (c *APIClient) GetResourceOperations(resourceID string) ([]ResourceOperation, error) {
+	var operations []ResourceOperation
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("catalog-service/api/consumer/resources/%s/actions?page=%d&limit=100",
+			resourceID, page)
+
+		template := new(resourceOperationList)
+		apiError := new(APIError)
+		_, err := c.HTTPClient.New().Get(path).Receive(template, apiError)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !apiError.isEmpty() {
+			return nil, apiError
+		}
+
+		operations = append(operations, template.Content...)
+		if page >= template.Metadata.TotalPages {
+			return operations, nil
+		}
+	}
+}
+
+//operationNotFoundError - is returned when a resource does not offer an operation
+type operationNotFoundError struct {
+	operationName       string
+	availableOperations []string
+}
+
+func (e operationNotFoundError) Error() string {
+	return fmt.Sprintf("operation %s is not available, available operations: %s",
+		e.operationName, strings.Join(e.availableOperations, ", "))
+}
 
+//findResourceOperation - To find an operation by its ID, binding ID or display name.
+//Display names are matched case-insensitively and have to be unique.
+func findResourceOperation(operations []ResourceOperation, operationName string) (*ResourceOperation, error) {
+	var matches []ResourceOperation
+	var availableOperations []string
+	for _, operation := range operations {
+		if operation.ID == operationName || operation.BindingID == operationName {
+			return &operation, nil
+		}
+		if strings.EqualFold(operation.Name, operationName) {
+			matches = append(matches, operation)
 		}
+		availableOperations = append(availableOperations, operation.Name)
+	}
+
+	switch len(matches) {
+	case 0:
+		sort.Strings(availableOperations)
+		return nil, operationNotFoundError{operationName, availableOperations}
+	case 1:
+		return &matches[0], nil
+	}
 
+	var matchIDs []string
+	for _, operation := range matches {
+		matchIDs = append(matchIDs, fmt.Sprintf("%s (%s)", operation.ID, operation.BindingID))
 	}
-	//Return action URL
-	return actionURL
+	return nil, fmt.Errorf("operation name %s is ambiguous, use one of the operation IDs: %s",
+		operationName, strings.Join(matchIDs, ", "))
 }
 
-//GetPowerOffActionTemplate - To read power-off action template from provided resource configuration
-func (c *APIClient) GetPowerOffActionTemplate(resourceViewsTemplate *ResourceViewsTemplate) (*ActionTemplate, *ResourceViewsTemplate, error) {
-	//Set resource power-off URL label
-	actionURL := "GET Template: {com.vmware.csp.component.iaas.proxy.provider@resource.action.name.machine.PowerOff}"
-	//Set get action URL function call
-	return c.GetActionTemplate(resourceViewsTemplate, actionURL)
+//resourceActionURLs - To form the template and request URLs of an operation on a resource
+func resourceActionURLs(resourceID string, operationID string) (string, string) {
+	requestURL := fmt.Sprintf("catalog-service/api/consumer/resources/%s/actions/%s/requests",
+		resourceID, operationID)
+	return requestURL + "/template", requestURL
 }
 
-//GetDestroyActionTemplate - To read destroy resource action template from provided resource configuration
-func (c *APIClient) GetDestroyActionTemplate(resourceViewsTemplate *ResourceViewsTemplate) (*ActionTemplate, *ResourceViewsTemplate, error) {
-	//Set destroy resource URL label
-	actionURL := "GET Template: {com.vmware.csp.component.cafe.composition@resource.action.deployment.destroy.name}"
-	//Set get action URL function call
-	return c.GetActionTemplate(resourceViewsTemplate, actionURL)
+//GetComponentActions - To find an operation by ID, binding ID or display name on every resource of a deployment.
+//Resources which do not offer the operation are skipped, it is an error if no resource offers it.
+//That error lists the operations available on any of the resources.
+func (c *APIClient) GetComponentActions(resourceViewsTemplate *ResourceViewsTemplate, operationName string) ([]ComponentAction, error) {
+	var componentActions []ComponentAction
+	availableOperations := make(map[string]bool)
+	for _, resource := range resourceViewsTemplate.Content {
+		operations, err := c.GetResourceOperations(resource.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		operation, err := findResourceOperation(operations, operationName)
+		if notFoundErr, notFound := err.(operationNotFoundError); notFound {
+			for _, name := range notFoundErr.availableOperations {
+				availableOperations[name] = true
+			}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: %v", resource.ResourceID, err)
+		}
+
+		templateURL, requestURL := resourceActionURLs(resource.ResourceID, operation.ID)
+		componentActions = append(componentActions, ComponentAction{
+			ResourceID:    resource.ResourceID,
+			ComponentName: resource.componentName(),
+			Operation:     *operation,
+			TemplateURL:   templateURL,
+			RequestURL:    requestURL,
+		})
+	}
+
+	if len(componentActions) == 0 {
+		notFoundErr := operationNotFoundError{operationName: operationName}
+		for name := range availableOperations {
+			notFoundErr.availableOperations = append(notFoundErr.availableOperations, name)
+		}
+		sort.Strings(notFoundErr.availableOperations)
+		return nil, notFoundErr
+	}
+	return componentActions, nil
+}
+
+//GetComponentActionTemplate - To read the action template of an operation on a resource
+func (c *APIClient) GetComponentActionTemplate(componentAction ComponentAction) (*ActionTemplate, error) {
+	actionTemplate := new(ActionTemplate)
+	apiError := new(APIError)
+
+	_, err := c.HTTPClient.New().Get(componentAction.TemplateURL).Receive(actionTemplate, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+
+	if actionTemplate.Data == nil {
+		actionTemplate.Data = make(map[string]interface{})
+	}
+	return actionTemplate, nil
+}
+
+//PerformResourceOperation - To run an operation on the one resource of the given resources which
+//offers it, with the given values set in its action template. Returns the ID of the action request.
+func (c *APIClient) PerformResourceOperation(resourceViewsTemplate *ResourceViewsTemplate, operationName string, data map[string]interface{}) (string, error) {
+	componentActions, err := c.GetComponentActions(resourceViewsTemplate, operationName)
+	if err != nil {
+		return "", err
+	}
+	if len(componentActions) > 1 {
+		return "", fmt.Errorf("operation %s is offered by %d resources, expected one",
+			operationName, len(componentActions))
+	}
+
+	actionTemplate, err := c.GetComponentActionTemplate(componentActions[0])
+	if err != nil {
+		return "", fmt.Errorf("action template failed to load: %v", err)
+	}
+	for field, value := range data {
+		actionTemplate.Data[field] = value
+	}
+	return c.PerformAction(actionTemplate, componentActions[0].RequestURL)
 }
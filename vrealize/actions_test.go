@@ -0,0 +1,79 @@
+package vrealize
+
+import (
+	"gopkg.in/jarcoal/httpmock.v1"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestFindResourceOperation(t *testing.T) {
+	operations := []ResourceOperation{
+		{ID: "0f1aa3fd-bd4b-4d55-8ee5-b1a36a1f1e0a", Name: "Reboot", BindingID: "Infrastructure.Virtual.Action.Reboot"},
+		{ID: "7e4a5e8e-3cd1-4d49-9f2b-5a0fb1e2c9a1", Name: "Install Software", BindingID: "Infrastructure.Virtual.Action.InstallSoftware"},
+		{ID: "a5bd9e6f-52d6-4d0b-95f1-6de5e1aa6a4d", Name: "Install Software", BindingID: "com.mycompany.InstallSoftware"},
+	}
+
+	for _, operationName := range []string{"Reboot", "reboot", "0f1aa3fd-bd4b-4d55-8ee5-b1a36a1f1e0a", "Infrastructure.Virtual.Action.Reboot"} {
+		operation, err := findResourceOperation(operations, operationName)
+		if err != nil {
+			t.Errorf("Failed to find operation %s: %v", operationName, err)
+		} else if operation.ID != "0f1aa3fd-bd4b-4d55-8ee5-b1a36a1f1e0a" {
+			t.Errorf("Unexpected operation %s for %s", operation.ID, operationName)
+		}
+	}
+
+	if _, err := findResourceOperation(operations, "Install Software"); err == nil {
+		t.Errorf("Ambiguous operation name did not fail.")
+	}
+
+	operation, err := findResourceOperation(operations, "a5bd9e6f-52d6-4d0b-95f1-6de5e1aa6a4d")
+	if err != nil || operation.BindingID != "com.mycompany.InstallSoftware" {
+		t.Errorf("Failed to find operation by ID %v", err)
+	}
+
+	if _, err := findResourceOperation(operations, "Create Snapshot"); err == nil {
+		t.Errorf("Found an operation which is not available.")
+	} else if _, notFound := err.(operationNotFoundError); !notFound {
+		t.Errorf("Unexpected error type %v", err)
+	}
+}
+
+func TestAPIClient_GetComponentActions(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost/catalog-service/api/consumer/resources/b313acd6-0738-439c-b601-e3ebf9ebb49b/actions",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(200, `{"links":[],"content":[{"@type":"ConsumerResourceOperation","name":"Destroy","description":"Destroy a deployment","type":"ACTION","id":"3da0ca14-e7e2-4d7b-89cb-c6db57440d72","bindingId":"composition.resource.action.deployment.destroy","providerTypeId":"com.vmware.csp.component.cafe.composition"}],"metadata":{"size":100,"totalElements":1,"totalPages":1,"number":1,"offset":0}}`), nil
+		})
+	httpmock.RegisterResponder("GET", "http://localhost/catalog-service/api/consumer/resources/51bf8bd7-8553-4b0d-b580-41ab0cfaf9a5/actions",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(200, `{"links":[],"content":[{"@type":"ConsumerResourceOperation","name":"Reboot","description":"Reboot a machine","type":"ACTION","id":"0f1aa3fd-bd4b-4d55-8ee5-b1a36a1f1e0a","bindingId":"Infrastructure.Virtual.Action.Reboot","providerTypeId":"com.vmware.csp.component.iaas.proxy.provider"}],"metadata":{"size":100,"totalElements":1,"totalPages":1,"number":1,"offset":0}}`), nil
+		})
+
+	template := &ResourceViewsTemplate{Content: []ResourceView{
+		{ResourceID: "b313acd6-0738-439c-b601-e3ebf9ebb49b", ResourceType: deploymentResourceType},
+		{ResourceID: "51bf8bd7-8553-4b0d-b580-41ab0cfaf9a5", Data: map[string]interface{}{"machineId": "4fc33663-992d-49f8-af17-df7ce4831aa0", "Component": "CentOS_6.3"}},
+	}}
+
+	componentActions, err := client.GetComponentActions(template, "Reboot")
+	if err != nil {
+		t.Fatalf("Failed to get component actions %v", err)
+	}
+	if len(componentActions) != 1 || componentActions[0].ComponentName != "CentOS_6.3" {
+		t.Fatalf("Unexpected component actions %v", componentActions)
+	}
+	if componentActions[0].RequestURL != "catalog-service/api/consumer/resources/51bf8bd7-8553-4b0d-b580-41ab0cfaf9a5/actions/0f1aa3fd-bd4b-4d55-8ee5-b1a36a1f1e0a/requests" {
+		t.Errorf("Unexpected request URL %s", componentActions[0].RequestURL)
+	}
+
+	_, err = client.GetComponentActions(template, "Create Snapshot")
+	notFoundErr, notFound := err.(operationNotFoundError)
+	if !notFound {
+		t.Fatalf("Expected an operation not found error, got %v", err)
+	}
+	if !reflect.DeepEqual(notFoundErr.availableOperations, []string{"Destroy", "Reboot"}) {
+		t.Errorf("Expected the operations of all resources, got %v", notFoundErr.availableOperations)
+	}
+}
@@ -18,3 +18,19 @@ func (e APIError) Error() string {
 func (e APIError) isEmpty() bool {
 	return len(e.Errors) == 0
 }
+
+//NotFoundError - is returned when a requested entity does not exist (anymore)
+type NotFoundError struct {
+	Entity string
+	ID     string
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Entity, e.ID)
+}
+
+//isNotFound - To check whether an entity was deleted outside terraform
+func isNotFound(err error) bool {
+	_, ok := err.(NotFoundError)
+	return ok
+}
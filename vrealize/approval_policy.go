@@ -0,0 +1,145 @@
+package vrealize
+
+import (
+	"fmt"
+)
+
+//Approval policy states, phases and level settings
+const (
+	approvalPolicyPublished = "PUBLISHED"
+	approvalPolicyDraft     = "DRAFT"
+	approvalPolicyRetired   = "RETIRED"
+
+	catalogRequestPolicyType = "com.vmware.cafe.catalog.request"
+	preApprovalPhaseSuffix   = ".pre"
+	postApprovalPhaseSuffix  = ".post"
+
+	approvalModeAny      = "ANY"
+	approvalModeAll      = "ALL"
+	specificApproverType = "SPECIFIC_USERS"
+)
+
+//ApprovalPolicy - This struct holds an approval policy with its pre and post approval phases
+type ApprovalPolicy struct {
+	ID           string          `json:"id,omitempty"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	State        string          `json:"state"`
+	PolicyTypeID string          `json:"policyTypeId"`
+	TenantID     string          `json:"tenantId"`
+	Phases       []ApprovalPhase `json:"phases"`
+}
+
+//ApprovalPhase - This struct holds the approval levels run before or after provisioning
+type ApprovalPhase struct {
+	ID          string          `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	PhaseTypeID string          `json:"phaseTypeId"`
+	Levels      []ApprovalLevel `json:"levels"`
+}
+
+//ApprovalLevel - This struct holds the approvers of a level and the condition it applies on
+type ApprovalLevel struct {
+	ID             string                 `json:"id,omitempty"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description"`
+	LevelNumber    int                    `json:"levelNumber"`
+	ApprovalMode   string                 `json:"approvalMode"`
+	ApproverType   string                 `json:"approverType"`
+	Approvers      []Approver             `json:"approvers"`
+	AlwaysRequired bool                   `json:"alwaysRequired"`
+	Condition      map[string]interface{} `json:"condition,omitempty"`
+}
+
+//Approver - This struct holds a user or group approving a level
+type Approver struct {
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+//GetApprovalPolicy - To read an approval policy by its id
+func (c *APIClient) GetApprovalPolicy(policyID string) (*ApprovalPolicy, error) {
+	path := fmt.Sprintf("/approval-service/api/policies/%s", policyID)
+
+	policy := new(ApprovalPolicy)
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Get(path).Receive(policy, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, NotFoundError{"approval policy", policyID}
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return policy, nil
+}
+
+//CreateApprovalPolicy - To create an approval policy for the tenant of the client
+func (c *APIClient) CreateApprovalPolicy(policy *ApprovalPolicy) (*ApprovalPolicy, error) {
+	policy.TenantID = c.Tenant
+
+	created := new(ApprovalPolicy)
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Post("/approval-service/api/policies").
+		BodyJSON(policy).Receive(created, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return created, nil
+}
+
+//UpdateApprovalPolicy - To replace an approval policy
+func (c *APIClient) UpdateApprovalPolicy(policy *ApprovalPolicy) error {
+	path := fmt.Sprintf("/approval-service/api/policies/%s", policy.ID)
+	policy.TenantID = c.Tenant
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(path).BodyJSON(policy).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//DeleteApprovalPolicy - To delete an approval policy, published policies are retired first
+func (c *APIClient) DeleteApprovalPolicy(policyID string) error {
+	policy, err := c.GetApprovalPolicy(policyID)
+	if err != nil {
+		return err
+	}
+	if policy.State == approvalPolicyPublished {
+		policy.State = approvalPolicyRetired
+		if err := c.UpdateApprovalPolicy(policy); err != nil {
+			return err
+		}
+	}
+
+	path := fmt.Sprintf("/approval-service/api/policies/%s", policyID)
+	apiError := new(APIError)
+	_, err = c.HTTPClient.New().Delete(path).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
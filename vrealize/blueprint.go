@@ -0,0 +1,195 @@
+package vrealize
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v2"
+)
+
+//Composite blueprint statuses
+const (
+	blueprintDraft     = "DRAFT"
+	blueprintPublished = "PUBLISHED"
+)
+
+//parseBlueprintContent - To parse a YAML or JSON blueprint into its JSON document form.
+//JSON is a subset of YAML, so both are read by the YAML parser.
+func parseBlueprintContent(content string) (map[string]interface{}, error) {
+	var document interface{}
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		return nil, fmt.Errorf("blueprint content is neither valid YAML nor JSON: %v", err)
+	}
+
+	//Round trip through JSON so numbers are read back the same way as from the API
+	data, err := json.Marshal(convertYAMLValue(document))
+	if err != nil {
+		return nil, err
+	}
+	var blueprint map[string]interface{}
+	if err := json.Unmarshal(data, &blueprint); err != nil {
+		return nil, fmt.Errorf("blueprint content has to be a document: %v", err)
+	}
+	if id, _ := blueprint["id"].(string); len(id) == 0 {
+		return nil, fmt.Errorf("blueprint content has no id")
+	}
+	return blueprint, nil
+}
+
+//normalizeBlueprintContent - To render blueprint content as JSON with sorted keys,
+//so formatting and YAML vs JSON do not show up as a difference
+func normalizeBlueprintContent(content string) (string, error) {
+	blueprint, err := parseBlueprintContent(content)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(blueprint)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+//convertYAMLValue - The YAML parser reads mappings with interface keys, which JSON can not encode
+func convertYAMLValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			converted[fmt.Sprint(key)] = convertYAMLValue(item)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(typed))
+		for i, item := range typed {
+			converted[i] = convertYAMLValue(item)
+		}
+		return converted
+	}
+	return value
+}
+
+//blueprintContentContains - To check if the blueprint read from vRA still holds everything of
+//the configured content. vRA adds fields such as dates and the tenant, those are ignored.
+func blueprintContentContains(remote interface{}, configured interface{}) bool {
+	switch typed := configured.(type) {
+	case map[string]interface{}:
+		remoteMap, ok := remote.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, item := range typed {
+			if !blueprintContentContains(remoteMap[key], item) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		remoteList, ok := remote.([]interface{})
+		if !ok || len(remoteList) != len(typed) {
+			return false
+		}
+		for i, item := range typed {
+			if !blueprintContentContains(remoteList[i], item) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(remote, configured)
+}
+
+//GetBlueprint - To read a composite blueprint by its id
+func (c *APIClient) GetBlueprint(blueprintID string) (map[string]interface{}, error) {
+	path := fmt.Sprintf("/composition-service/api/blueprints/%s", blueprintID)
+
+	var blueprint map[string]interface{}
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Get(path).Receive(&blueprint, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, NotFoundError{"blueprint", blueprintID}
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return blueprint, nil
+}
+
+//CreateBlueprint - To create a composite blueprint, its id is part of the content
+func (c *APIClient) CreateBlueprint(blueprint map[string]interface{}) error {
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Post("/composition-service/api/blueprints").
+		BodyJSON(blueprint).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//UpdateBlueprint - To replace the content of a composite blueprint
+func (c *APIClient) UpdateBlueprint(blueprintID string, blueprint map[string]interface{}) error {
+	path := fmt.Sprintf("/composition-service/api/blueprints/%s", blueprintID)
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(path).BodyJSON(blueprint).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//SetBlueprintStatus - To publish or unpublish a composite blueprint
+func (c *APIClient) SetBlueprintStatus(blueprintID string, status string) error {
+	path := fmt.Sprintf("/composition-service/api/blueprints/%s/status", blueprintID)
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(path).
+		BodyJSON(map[string]string{"status": status}).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//DeleteBlueprint - To delete a composite blueprint
+func (c *APIClient) DeleteBlueprint(blueprintID string) error {
+	path := fmt.Sprintf("/composition-service/api/blueprints/%s", blueprintID)
+
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Delete(path).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == 404 {
+		return NotFoundError{"blueprint", blueprintID}
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package vrealize
+
+import (
+	"testing"
+)
+
+func TestNormalizeBlueprintContent(t *testing.T) {
+	yamlContent := `
+id: CentOS_7
+name: CentOS 7
+components:
+  vSphere_Machine:
+    type: Infrastructure.CatalogItem.Machine.Virtual.vSphere
+    data:
+      cpu:
+        default: 1
+        max: 4
+`
+	jsonContent := `{"name": "CentOS 7", "id": "CentOS_7",
+		"components": {"vSphere_Machine": {"data": {"cpu": {"max": 4, "default": 1}},
+		"type": "Infrastructure.CatalogItem.Machine.Virtual.vSphere"}}}`
+
+	fromYAML, err := normalizeBlueprintContent(yamlContent)
+	if err != nil {
+		t.Fatalf("Failed to normalize YAML blueprint %v", err)
+	}
+	fromJSON, err := normalizeBlueprintContent(jsonContent)
+	if err != nil {
+		t.Fatalf("Failed to normalize JSON blueprint %v", err)
+	}
+	if fromYAML != fromJSON {
+		t.Errorf("Expected the same normalized content, got %v and %v", fromYAML, fromJSON)
+	}
+
+	if _, err := normalizeBlueprintContent("name: CentOS 7"); err == nil {
+		t.Errorf("Normalized a blueprint without an id.")
+	}
+}
+
+func TestBlueprintContentContains(t *testing.T) {
+	configured, _ := parseBlueprintContent(`{"id":"CentOS_7","name":"CentOS 7","components":{"vSphere_Machine":{"data":{"cpu":{"default":1}}}}}`)
+	remote, _ := parseBlueprintContent(`{"id":"CentOS_7","name":"CentOS 7","status":"PUBLISHED","tenantId":"vsphere.local","components":{"vSphere_Machine":{"data":{"cpu":{"default":1,"fixed":null}}}}}`)
+	changed, _ := parseBlueprintContent(`{"id":"CentOS_7","name":"CentOS 7","components":{"vSphere_Machine":{"data":{"cpu":{"default":2}}}}}`)
+
+	if !blueprintContentContains(remote, configured) {
+		t.Errorf("Fields added by vRA were reported as a difference.")
+	}
+	if blueprintContentContains(changed, configured) {
+		t.Errorf("Changed cpu default was not reported as a difference.")
+	}
+}
@@ -0,0 +1,342 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+//Business group roles
+const (
+	businessGroupManagerRole          = "CSP_SUBTENANT_MANAGER"
+	businessGroupSupportRole          = "CSP_SUPPORT"
+	businessGroupUserRole             = "CSP_CONSUMER"
+	businessGroupSharedAccessUserRole = "CSP_CONSUMER_WITH_SHARED_ACCESS"
+)
+
+//businessGroupMemberRoles - business group roles whose principals are allowed
+//to own a deployment requested on their behalf
+var businessGroupMemberRoles = []string{
+	businessGroupManagerRole,
+	businessGroupUserRole,
+	businessGroupSharedAccessUserRole,
+}
+
+//BusinessGroup - This struct holds a business group, called subtenant by the identity service
+type BusinessGroup struct {
+	ID            string        `json:"id,omitempty"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description"`
+	Tenant        string        `json:"tenant"`
+	ExtensionData ExtensionData `json:"extensionData"`
+}
+
+//ExtensionData - This struct holds the custom key value entries of an identity entity
+type ExtensionData struct {
+	Entries []ExtensionEntry `json:"entries"`
+}
+
+//ExtensionEntry - This struct holds a key and its typed value
+type ExtensionEntry struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+//businessGroupList - This struct holds one page of business groups
+type businessGroupList struct {
+	Content  []BusinessGroup `json:"content"`
+	Metadata Metadata        `json:"metadata"`
+}
+
+//PrincipalID - This struct holds the domain and name of an identity principal
+type PrincipalID struct {
+	Domain string `json:"domain"`
+	Name   string `json:"name"`
+}
+
+//String - principal in the name@domain form used by requestedFor
+func (p PrincipalID) String() string {
+	return fmt.Sprintf("%s@%s", p.Name, p.Domain)
+}
+
+//Principal - This struct holds a principal assigned to a business group role
+type Principal struct {
+	PrincipalID PrincipalID `json:"principalId"`
+	Name        string      `json:"name"`
+}
+
+//principalList - This struct holds one page of principals of a business group role
+type principalList struct {
+	Content  []Principal `json:"content"`
+	Metadata Metadata    `json:"metadata"`
+}
+
+//GetBusinessGroup - To read a business group by its id
+func (c *APIClient) GetBusinessGroup(businessGroupID string) (*BusinessGroup, error) {
+	path := fmt.Sprintf("/identity/api/tenants/%s/subtenants/%s", c.Tenant, businessGroupID)
+
+	businessGroup := new(BusinessGroup)
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Get(path).Receive(businessGroup, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, NotFoundError{"business group", businessGroupID}
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return businessGroup, nil
+}
+
+//GetBusinessGroupByName - To read a business group by its exact name
+func (c *APIClient) GetBusinessGroupByName(name string) (*BusinessGroup, error) {
+	filter := fmt.Sprintf("name eq '%s'", strings.Replace(name, "'", "''", -1))
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("limit", "100")
+		query.Set("$filter", filter)
+		path := fmt.Sprintf("/identity/api/tenants/%s/subtenants?%s", c.Tenant, query.Encode())
+
+		template := new(businessGroupList)
+		apiError := new(APIError)
+		_, err := c.HTTPClient.New().Get(path).Receive(template, apiError)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !apiError.isEmpty() {
+			return nil, apiError
+		}
+
+		//The filter narrows the list down, the name still has to match exactly
+		for _, businessGroup := range template.Content {
+			if businessGroup.Name == name {
+				return &businessGroup, nil
+			}
+		}
+		if page >= template.Metadata.TotalPages {
+			return nil, fmt.Errorf("No business group found with name %s", name)
+		}
+	}
+}
+
+//GetBusinessGroupRolePrincipals - To read the principals assigned to a role of a business group
+func (c *APIClient) GetBusinessGroupRolePrincipals(businessGroupID string, roleID string) ([]Principal, error) {
+	var principals []Principal
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("/identity/api/tenants/%s/subtenants/%s/roles/%s/principals?page=%d&limit=100",
+			c.Tenant, businessGroupID, roleID, page)
+
+		template := new(principalList)
+		apiError := new(APIError)
+		_, err := c.HTTPClient.New().Get(path).Receive(template, apiError)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !apiError.isEmpty() {
+			return nil, apiError
+		}
+
+		principals = append(principals, template.Content...)
+		if page >= template.Metadata.TotalPages {
+			return principals, nil
+		}
+	}
+}
+
+//CreateBusinessGroup - To create a business group in the tenant of the client
+func (c *APIClient) CreateBusinessGroup(businessGroup *BusinessGroup) (*BusinessGroup, error) {
+	path := fmt.Sprintf("/identity/api/tenants/%s/subtenants", c.Tenant)
+	businessGroup.Tenant = c.Tenant
+
+	created := new(BusinessGroup)
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Post(path).BodyJSON(businessGroup).Receive(created, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return created, nil
+}
+
+//UpdateBusinessGroup - To update name, description and extension data of a business group
+func (c *APIClient) UpdateBusinessGroup(businessGroup *BusinessGroup) error {
+	path := fmt.Sprintf("/identity/api/tenants/%s/subtenants/%s", c.Tenant, businessGroup.ID)
+	businessGroup.Tenant = c.Tenant
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(path).BodyJSON(businessGroup).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//DeleteBusinessGroup - To delete a business group
+func (c *APIClient) DeleteBusinessGroup(businessGroupID string) error {
+	path := fmt.Sprintf("/identity/api/tenants/%s/subtenants/%s", c.Tenant, businessGroupID)
+
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Delete(path).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == 404 {
+		return NotFoundError{"business group", businessGroupID}
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//SetBusinessGroupRolePrincipals - To replace the principals assigned to a role of a business group
+func (c *APIClient) SetBusinessGroupRolePrincipals(businessGroupID string, roleID string, principals []PrincipalID) error {
+	path := fmt.Sprintf("/identity/api/tenants/%s/subtenants/%s/roles/%s/principals",
+		c.Tenant, businessGroupID, roleID)
+	if principals == nil {
+		principals = []PrincipalID{}
+	}
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(path).BodyJSON(principals).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//parsePrincipalID - To split a name@domain principal into its name and domain
+func parsePrincipalID(principal string) (PrincipalID, error) {
+	separator := strings.LastIndex(principal, "@")
+	if separator <= 0 || separator == len(principal)-1 {
+		return PrincipalID{}, fmt.Errorf("principal %s is not in the name@domain form", principal)
+	}
+	return PrincipalID{Name: principal[:separator], Domain: principal[separator+1:]}, nil
+}
+
+//extensionValue - To read the string value of an extension data entry
+func (e ExtensionData) extensionValue(key string) string {
+	for _, entry := range e.Entries {
+		if entry.Key != key {
+			continue
+		}
+		//Extension values are typed literals, e.g. {"type":"string","value":"..."}
+		if literal, ok := entry.Value.(map[string]interface{}); ok {
+			value, _ := literal["value"].(string)
+			return value
+		}
+	}
+	return ""
+}
+
+//setExtensionValue - To set or, for an empty value, remove a string extension data entry
+func (e *ExtensionData) setExtensionValue(key string, value string) {
+	if len(value) == 0 {
+		e.setExtensionEntry(key, nil)
+		return
+	}
+	e.setExtensionEntry(key, literalValue("string", value))
+}
+
+//setExtensionEntry - To set or, for a nil value, remove an extension data entry
+func (e *ExtensionData) setExtensionEntry(key string, value interface{}) {
+	entries := []ExtensionEntry{}
+	for _, entry := range e.Entries {
+		if entry.Key != key {
+			entries = append(entries, entry)
+		}
+	}
+	if value != nil {
+		entries = append(entries, ExtensionEntry{Key: key, Value: value})
+	}
+	e.Entries = entries
+}
+
+//extensionEntry - To read the typed value of an extension data entry
+func (e ExtensionData) extensionEntry(key string) map[string]interface{} {
+	for _, entry := range e.Entries {
+		if entry.Key == key {
+			value, _ := entry.Value.(map[string]interface{})
+			return value
+		}
+	}
+	return nil
+}
+
+//literalValue - To build a typed literal, e.g. {"type":"string","value":"..."}
+func literalValue(valueType string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": valueType, "value": value}
+}
+
+//GetBusinessGroupMembers - To read all principals which are members of a business group
+func (c *APIClient) GetBusinessGroupMembers(businessGroupID string) ([]Principal, error) {
+	var members []Principal
+	for _, role := range businessGroupMemberRoles {
+		principals, err := c.GetBusinessGroupRolePrincipals(businessGroupID, role)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, principals...)
+	}
+	return members, nil
+}
+
+//principalNames - To read the distinct name@domain of principals in their original order
+func principalNames(principals []Principal) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, principal := range principals {
+		name := principal.PrincipalID.String()
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+//validateRequestedFor - To check that a user is a member of the business group
+//before a deployment is requested on its behalf
+func (c *APIClient) validateRequestedFor(businessGroupID string, requestedFor string) error {
+	members, err := c.GetBusinessGroupMembers(businessGroupID)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if strings.EqualFold(member.PrincipalID.String(), requestedFor) {
+			return nil
+		}
+	}
+	log.Printf("validateRequestedFor->members %v\n", members)
+	return fmt.Errorf("%s is not a member of business group %s", requestedFor, businessGroupID)
+}
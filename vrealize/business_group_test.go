@@ -0,0 +1,99 @@
+package vrealize
+
+import (
+	"gopkg.in/jarcoal/httpmock.v1"
+	"testing"
+)
+
+func TestAPIClient_validateRequestedFor(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost/identity/api/tenants/vsphere.local/subtenants/"+
+		"53619006-56bb-4788-9723-9eab79752cc1/roles/CSP_SUBTENANT_MANAGER/principals",
+		httpmock.NewStringResponder(200, `{"links":[],"content":[{"principalId":{"domain":"corp.local","name":"jason"},"name":"Jason Cloud Admin"}],"metadata":{"size":100,"totalElements":1,"totalPages":1,"number":1,"offset":0}}`))
+	httpmock.RegisterResponder("GET", "http://localhost/identity/api/tenants/vsphere.local/subtenants/"+
+		"53619006-56bb-4788-9723-9eab79752cc1/roles/CSP_CONSUMER/principals",
+		httpmock.NewStringResponder(200, `{"links":[],"content":[{"principalId":{"domain":"corp.local","name":"devuser"},"name":"Dev User"}],"metadata":{"size":100,"totalElements":1,"totalPages":1,"number":1,"offset":0}}`))
+	httpmock.RegisterResponder("GET", "http://localhost/identity/api/tenants/vsphere.local/subtenants/"+
+		"53619006-56bb-4788-9723-9eab79752cc1/roles/CSP_CONSUMER_WITH_SHARED_ACCESS/principals",
+		httpmock.NewStringResponder(200, `{"links":[],"content":[],"metadata":{"size":100,"totalElements":0,"totalPages":1,"number":1,"offset":0}}`))
+
+	err := client.validateRequestedFor("53619006-56bb-4788-9723-9eab79752cc1", "DevUser@corp.local")
+	if err != nil {
+		t.Errorf("Failed to validate business group member %v", err)
+	}
+
+	err = client.validateRequestedFor("53619006-56bb-4788-9723-9eab79752cc1", "stranger@corp.local")
+	if err == nil {
+		t.Errorf("Requested for a user outside of the business group.")
+	}
+}
+
+func TestAPIClient_GetBusinessGroupByName(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost/identity/api/tenants/vsphere.local/subtenants",
+		httpmock.NewStringResponder(200, `{"links":[],"content":[{"@type":"Subtenant","id":"7a2bd3c1-5d8e-4c3e-9f26-2e7b4a6f0d11","name":"Content Dev","description":"","tenant":"vsphere.local","extensionData":{"entries":[]}},{"@type":"Subtenant","id":"53619006-56bb-4788-9723-9eab79752cc1","name":"Content","description":"Content team","tenant":"vsphere.local","extensionData":{"entries":[]}}],"metadata":{"size":100,"totalElements":2,"totalPages":1,"number":1,"offset":0}}`))
+
+	businessGroup, err := client.GetBusinessGroupByName("Content")
+	if err != nil {
+		t.Fatalf("Failed to get business group %v", err)
+	}
+	if businessGroup.ID != "53619006-56bb-4788-9723-9eab79752cc1" {
+		t.Errorf("Expected business group 53619006-56bb-4788-9723-9eab79752cc1, got %v", businessGroup.ID)
+	}
+
+	if _, err := client.GetBusinessGroupByName("Finance"); err == nil {
+		t.Errorf("Found a business group which does not exist.")
+	}
+}
+
+func TestParsePrincipalID(t *testing.T) {
+	principal, err := parsePrincipalID("jason@corp.local")
+	if err != nil || principal.Name != "jason" || principal.Domain != "corp.local" {
+		t.Errorf("Failed to parse principal %v %v", principal, err)
+	}
+	if principal.String() != "jason@corp.local" {
+		t.Errorf("Expected jason@corp.local, got %v", principal.String())
+	}
+
+	for _, invalid := range []string{"jason", "@corp.local", "jason@"} {
+		if _, err := parsePrincipalID(invalid); err == nil {
+			t.Errorf("Parsed invalid principal %s", invalid)
+		}
+	}
+}
+
+func TestExtensionData(t *testing.T) {
+	extensionData := ExtensionData{Entries: []ExtensionEntry{
+		{Key: "iaas-machine-prefix", Value: map[string]interface{}{"type": "string", "value": "5f6a4b3c"}},
+		{Key: "custom", Value: map[string]interface{}{"type": "string", "value": "kept"}},
+	}}
+
+	if extensionData.extensionValue(businessGroupMachinePrefixKey) != "5f6a4b3c" {
+		t.Errorf("Failed to read machine prefix %v", extensionData)
+	}
+
+	extensionData.setExtensionValue(businessGroupManagerEmailKey, "ops@corp.local")
+	extensionData.setExtensionValue(businessGroupMachinePrefixKey, "")
+	if extensionData.extensionValue(businessGroupManagerEmailKey) != "ops@corp.local" ||
+		extensionData.extensionValue(businessGroupMachinePrefixKey) != "" ||
+		extensionData.extensionValue("custom") != "kept" {
+		t.Errorf("Unexpected extension data %v", extensionData)
+	}
+}
+
+func TestAPIClient_GetBusinessGroupNotFound(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost/identity/api/tenants/vsphere.local/subtenants/53619006-56bb-4788-9723-9eab79752cc1",
+		httpmock.NewStringResponder(404, `{"errors":[{"code":10101,"message":"Subtenant not found","systemMessage":"Subtenant not found"}]}`))
+
+	_, err := client.GetBusinessGroup("53619006-56bb-4788-9723-9eab79752cc1")
+	if !isNotFound(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
@@ -3,6 +3,10 @@ package vrealize
 import (
 	"fmt"
 	"log"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -18,14 +22,21 @@ type CatalogItemTemplate struct {
 	Data            map[string]interface{} `json:"data"`
 }
 
-//catalogName - This struct holds catalog name from json response.
-type catalogName struct {
-	Name string `json:"name"`
+//catalogItemDetails - This struct holds catalog item details from json response.
+type catalogItemDetails struct {
+	ID                 string    `json:"id"`
+	Name               string    `json:"name"`
+	Description        string    `json:"description"`
+	Status             string    `json:"status"`
+	Version            int       `json:"version"`
+	ServiceRef         Reference `json:"serviceRef"`
+	CatalogItemTypeRef Reference `json:"catalogItemTypeRef"`
 }
 
 //CatalogItem - This struct holds the value of response of catalog item list
 type CatalogItem struct {
-	CatalogItem catalogName `json:"catalogItem"`
+	CatalogItem           catalogItemDetails     `json:"catalogItem"`
+	EntitledOrganizations []EntitledOrganization `json:"entitledOrganizations"`
 }
 
 //GetCatalogItem - set call to read catalog item provided in terraform config file
@@ -54,26 +65,54 @@ func (c *APIClient) GetCatalogItem(uuid string) (*CatalogItemTemplate, error) {
 	return template, nil
 }
 
+//Reference - is used to store a reference to another vRA entity
+type Reference struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+//EntitledOrganization - is used to store a tenant and business group a catalog item is entitled for
+type EntitledOrganization struct {
+	TenantRef      string `json:"tenantRef"`
+	TenantLabel    string `json:"tenantLabel"`
+	SubtenantRef   string `json:"subtenantRef"`
+	SubtenantLabel string `json:"subtenantLabel"`
+}
+
+//CatalogItemView - is used to store an entitled catalog item of the catalog item list
+type CatalogItemView struct {
+	CatalogItemID         string                 `json:"catalogItemId"`
+	Name                  string                 `json:"name"`
+	Description           string                 `json:"description"`
+	EntitledOrganizations []EntitledOrganization `json:"entitledOrganizations"`
+	CatalogItemTypeRef    Reference              `json:"catalogItemTypeRef"`
+	ServiceRef            Reference              `json:"serviceRef"`
+}
+
 type entitledCatalogItemViews struct {
-	Links    interface{} `json:"links"`
-	Content  interface{} `json:"content"`
-	Metadata Metadata    `json:"metadata"`
+	Links    interface{}       `json:"links"`
+	Content  []CatalogItemView `json:"content"`
+	Metadata Metadata          `json:"metadata"`
 }
 
 //Metadata - Metadata  used to store metadata of resource list response
 type Metadata struct {
+	Size          int `json:"size"`
 	TotalElements int `json:"totalElements"`
+	TotalPages    int `json:"totalPages"`
+	Number        int `json:"number"`
+	Offset        int `json:"offset"`
 }
 
-//readCatalogNameById - To read name of catalog from vRA using catalog_name
-func (c *APIClient) readCatalogNameByID(catalogID string) (interface{}, error) {
-	//Form a path to read catalog template via REST call
+//GetEntitledCatalogItem - To read an entitled catalog item from vRA using catalog_id
+func (c *APIClient) GetEntitledCatalogItem(catalogID string) (*CatalogItem, error) {
+	//Form a path to read catalog item via REST call
 	path := fmt.Sprintf("/catalog-service/api/consumer/entitledCatalogItems/"+
 		"%s", catalogID)
 
 	template := new(CatalogItem)
 	apiError := new(APIError)
-	//Set REST call to get catalog template
+	//Set REST call to get catalog item
 	_, err := c.HTTPClient.New().Get(path).Receive(template, apiError)
 
 	if err != nil {
@@ -83,79 +122,271 @@ func (c *APIClient) readCatalogNameByID(catalogID string) (interface{}, error) {
 	if !apiError.isEmpty() {
 		return nil, apiError
 	}
+	return template, nil
+}
+
+//readCatalogNameById - To read name of catalog from vRA using catalog_id
+func (c *APIClient) readCatalogNameByID(catalogID string) (interface{}, error) {
+	template, err := c.GetEntitledCatalogItem(catalogID)
+	if err != nil {
+		return nil, err
+	}
 	//Return catalog Name
 	return template.CatalogItem.Name, nil
 }
 
-//readCatalogIdByName - To read id of catalog from vRA using catalog_name
-func (c *APIClient) readCatalogIDByName(catalogName string) (interface{}, error) {
-	var catalogID string
+//componentNames - To read the names of the blueprint components of a catalog item template
+func (t *CatalogItemTemplate) componentNames() []string {
+	var components []string
+	for field, value := range t.Data {
+		if reflect.ValueOf(value).Kind() == reflect.Map {
+			components = append(components, field)
+		}
+	}
+	sort.Strings(components)
+	return components
+}
 
-	log.Printf("readCatalogIdByName->catalog_name %v\n", catalogName)
+//catalogPageSize - number of catalog items requested per page
+const catalogPageSize = 100
 
-	//Set a call to read number of catalogs from vRA
-	path := fmt.Sprintf("catalog-service/api/consumer/entitledCatalogItemViews")
+//Catalog name match types
+const (
+	catalogNameMatchExact           = "exact"
+	catalogNameMatchCaseInsensitive = "case_insensitive"
+	catalogNameMatchRegex           = "regex"
+)
 
-	template := new(entitledCatalogItemViews)
-	apiError := new(APIError)
+//catalogNameMatchTypes - supported ways to match a catalog name
+var catalogNameMatchTypes = []string{
+	catalogNameMatchExact,
+	catalogNameMatchCaseInsensitive,
+	catalogNameMatchRegex,
+}
 
-	_, preErr := c.HTTPClient.New().Get(path).Receive(template, apiError)
+//catalogItemViewIterator - iterates page by page over the entitled catalog items matching a filter
+type catalogItemViewIterator struct {
+	client *APIClient
+	filter string
+	page   int
+	pages  int
+	items  []CatalogItemView
+}
 
-	if preErr != nil {
-		return nil, preErr
-	}
+//newCatalogItemViewIterator - To iterate over entitled catalog items, filter is an optional
+//OData filter expression evaluated by vRA
+func (c *APIClient) newCatalogItemViewIterator(filter string) *catalogItemViewIterator {
+	return &catalogItemViewIterator{client: c, filter: filter, pages: 1}
+}
 
-	if !apiError.isEmpty() {
-		return nil, apiError
+//Next - To read the next catalog item, the returned item is nil once all pages are read
+func (it *catalogItemViewIterator) Next() (*CatalogItemView, error) {
+	for len(it.items) == 0 {
+		if it.page >= it.pages {
+			return nil, nil
+		}
+		it.page++
+
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(it.page))
+		query.Set("limit", strconv.Itoa(catalogPageSize))
+		if len(it.filter) > 0 {
+			query.Set("$filter", it.filter)
+		}
+		path := "catalog-service/api/consumer/entitledCatalogItemViews?" + query.Encode()
+		log.Printf("catalogItemViewIterator->path %v\n", path)
+
+		template := new(entitledCatalogItemViews)
+		apiError := new(APIError)
+		_, err := it.client.HTTPClient.New().Get(path).Receive(template, apiError)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !apiError.isEmpty() {
+			return nil, apiError
+		}
+
+		it.pages = template.Metadata.TotalPages
+		it.items = template.Content
 	}
 
-	//Fetch all catalogs from vRA
-	path = fmt.Sprintf("catalog-service/api/consumer/entitledCatalogItemViews?page=1&"+
-		"limit=%d", template.Metadata.TotalElements)
-	_, err := c.HTTPClient.New().Get(path).Receive(template, apiError)
+	item := it.items[0]
+	it.items = it.items[1:]
+	return &item, nil
+}
 
+//catalogNameMatcher - To build a matcher for catalog names of the given match type
+//and the filter which narrows the catalog items down on the server side
+func catalogNameMatcher(catalogName string, matchType string) (func(string) bool, string, error) {
+	quotedName := strings.Replace(catalogName, "'", "''", -1)
+	switch matchType {
+	case catalogNameMatchExact, "":
+		return func(name string) bool {
+			return name == catalogName
+		}, fmt.Sprintf("name eq '%s'", quotedName), nil
+	case catalogNameMatchCaseInsensitive:
+		return func(name string) bool {
+			return strings.EqualFold(name, catalogName)
+		}, fmt.Sprintf("tolower(name) eq '%s'", strings.ToLower(quotedName)), nil
+	case catalogNameMatchRegex:
+		expression, err := regexp.Compile(catalogName)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid catalog name expression %s: %v", catalogName, err)
+		}
+		return expression.MatchString, "", nil
+	}
+	return nil, "", fmt.Errorf("unknown catalog name match type %s, expected one of %v",
+		matchType, catalogNameMatchTypes)
+}
+
+//findCatalogItemViews - To read all entitled catalog items whose name matches
+func (c *APIClient) findCatalogItemViews(catalogName string, matchType string) ([]CatalogItemView, error) {
+	matches, filter, err := catalogNameMatcher(catalogName, matchType)
 	if err != nil {
 		return nil, err
 	}
 
-	if !apiError.isEmpty() {
-		return nil, apiError
+	var catalogItems []CatalogItemView
+	iterator := c.newCatalogItemViewIterator(filter)
+	for {
+		catalogItem, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if catalogItem == nil {
+			return catalogItems, nil
+		}
+		if matches(catalogItem.Name) {
+			catalogItems = append(catalogItems, *catalogItem)
+		}
+	}
+}
+
+//readCatalogIdByName - To read id of catalog from vRA using catalog_name
+func (c *APIClient) readCatalogIDByName(catalogName string, matchType string) (string, error) {
+	log.Printf("readCatalogIdByName->catalog_name %v\n", catalogName)
+
+	catalogItems, err := c.findCatalogItemViews(catalogName, matchType)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(catalogItems) {
+	case 0:
+		return "", fmt.Errorf("No catalog found with name %s", catalogName)
+	case 1:
+		return catalogItems[0].CatalogItemID, nil
 	}
 
+	//If multiple catalogs match the provided catalog_name
+	//then raise an error and show all names of matching catalogs
 	var catalogNameArray []string
-	interfaceArray := template.Content.([]interface{})
-	catalogNameLen := len(catalogName)
-
-	//Iterate over all catalog results to find out matching catalog name
-	// provided in terraform configuration file
-	for i := range interfaceArray {
-		catalogItem := interfaceArray[i].(map[string]interface{})
-		if catalogNameLen <= len(catalogItem["name"].(string)) {
-			//If exact name matches then return respective catalog_id
-			//else if provided catalog matches as a substring in name then store it in array
-			if catalogName == catalogItem["name"].(string) {
-				return catalogItem["catalogItemId"].(interface{}), nil
-			} else if catalogName == catalogItem["name"].(string)[0:catalogNameLen] {
-				catalogNameArray = append(catalogNameArray, catalogItem["name"].(string))
-			}
+	for index, catalogItem := range catalogItems {
+		catalogNameArray = append(catalogNameArray, fmt.Sprintf("%d %s (%s)",
+			index+1, catalogItem.Name, catalogItem.CatalogItemID))
+	}
+	return "", fmt.Errorf("There are total %d catalog matching name %s.\n%s\n"+
+		"Please select from above.", len(catalogItems), catalogName, strings.Join(catalogNameArray, "\n"))
+}
+
+//catalogItemFilter - is used to store the filters of the catalog item list
+type catalogItemFilter struct {
+	nameRegex         *regexp.Regexp
+	serviceName       string
+	businessGroupID   string
+	businessGroupName string
+	itemType          string
+}
+
+//matches - To check a catalog item against all configured filters.
+//Service, business group and item type are compared case-insensitively,
+//the item type matches either the type id or its label.
+func (f catalogItemFilter) matches(catalogItem CatalogItemView) bool {
+	if f.nameRegex != nil && !f.nameRegex.MatchString(catalogItem.Name) {
+		return false
+	}
+	if len(f.serviceName) > 0 && !strings.EqualFold(catalogItem.ServiceRef.Label, f.serviceName) {
+		return false
+	}
+	if len(f.itemType) > 0 && !strings.EqualFold(catalogItem.CatalogItemTypeRef.ID, f.itemType) &&
+		!strings.EqualFold(catalogItem.CatalogItemTypeRef.Label, f.itemType) {
+		return false
+	}
+	if len(f.businessGroupID) == 0 && len(f.businessGroupName) == 0 {
+		return true
+	}
+	for _, organization := range catalogItem.EntitledOrganizations {
+		if (len(f.businessGroupID) == 0 || organization.SubtenantRef == f.businessGroupID) &&
+			(len(f.businessGroupName) == 0 || strings.EqualFold(organization.SubtenantLabel, f.businessGroupName)) {
+			return true
 		}
 	}
+	return false
+}
 
-	//If multiple catalogs are present with provided catalog_name
-	// then raise an error and show all names of catalogs with similar name
-	if len(catalogNameArray) > 0 {
-		for index := range catalogNameArray {
-			catalogNameArray[index] = strconv.Itoa(index+1) + " " + catalogNameArray[index]
+//listCatalogItemViews - To read all entitled catalog items matching the filter
+func (c *APIClient) listCatalogItemViews(filter catalogItemFilter) ([]CatalogItemView, error) {
+	var catalogItems []CatalogItemView
+	iterator := c.newCatalogItemViewIterator("")
+	for {
+		catalogItem, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if catalogItem == nil {
+			return catalogItems, nil
 		}
-		errorMessage := strings.Join(catalogNameArray, "\n")
-		fmt.Println(errorMessage)
+		if filter.matches(*catalogItem) {
+			catalogItems = append(catalogItems, *catalogItem)
+		}
+	}
+}
+
+//Catalog item statuses, an active catalog item is PUBLISHED and an inactive one RETIRED
+const (
+	catalogItemPublished = "PUBLISHED"
+	catalogItemRetired   = "RETIRED"
+	catalogItemStaging   = "STAGING"
+)
+
+//GetCatalogItemDefinition - To read a catalog item as administered by the catalog service.
+//The whole document is kept so that it can be sent back on update.
+func (c *APIClient) GetCatalogItemDefinition(catalogItemID string) (map[string]interface{}, error) {
+	path := fmt.Sprintf("/catalog-service/api/catalogItems/%s", catalogItemID)
+
+	var catalogItem map[string]interface{}
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Get(path).Receive(&catalogItem, apiError)
+
+	if err != nil {
+		return nil, err
+	}
 
-		return nil, fmt.Errorf("There are total %d catalog present with same name.\n%s\n"+
-			"Please select from above.", len(catalogNameArray), errorMessage)
+	if resp.StatusCode == 404 {
+		return nil, NotFoundError{"catalog item", catalogItemID}
 	}
 
 	if !apiError.isEmpty() {
 		return nil, apiError
 	}
-	return catalogID, nil
+	return catalogItem, nil
+}
+
+//UpdateCatalogItemDefinition - To replace a catalog item, e.g. to change its status or service
+func (c *APIClient) UpdateCatalogItemDefinition(catalogItemID string, catalogItem map[string]interface{}) error {
+	path := fmt.Sprintf("/catalog-service/api/catalogItems/%s", catalogItemID)
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(path).BodyJSON(catalogItem).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
 }
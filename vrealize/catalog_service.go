@@ -0,0 +1,119 @@
+package vrealize
+
+import (
+	"fmt"
+)
+
+//Catalog service statuses
+const (
+	catalogServiceActive   = "ACTIVE"
+	catalogServiceInactive = "INACTIVE"
+)
+
+//CatalogService - This struct holds a service grouping catalog items
+type CatalogService struct {
+	ID           string                `json:"id,omitempty"`
+	Name         string                `json:"name"`
+	Description  string                `json:"description"`
+	Status       string                `json:"status"`
+	IconID       string                `json:"iconId,omitempty"`
+	Version      int                   `json:"version,omitempty"`
+	Organization EntitledOrganization  `json:"organization"`
+	Owner        *EntitlementPrincipal `json:"owner,omitempty"`
+	SupportTeam  *EntitlementPrincipal `json:"supportTeam,omitempty"`
+	Hours        *ServiceHours         `json:"hours,omitempty"`
+	ChangeWindow interface{}           `json:"changeWindow,omitempty"`
+	NewDuration  interface{}           `json:"newDuration,omitempty"`
+}
+
+//ServiceHours - This struct holds the daily hours a service is supported
+type ServiceHours struct {
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+}
+
+//GetCatalogService - To read a catalog service by its id
+func (c *APIClient) GetCatalogService(serviceID string) (*CatalogService, error) {
+	path := fmt.Sprintf("/catalog-service/api/services/%s", serviceID)
+
+	service := new(CatalogService)
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Get(path).Receive(service, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, NotFoundError{"catalog service", serviceID}
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return service, nil
+}
+
+//CreateCatalogService - To create a catalog service for the tenant of the client
+func (c *APIClient) CreateCatalogService(service *CatalogService) (*CatalogService, error) {
+	path := "/catalog-service/api/services"
+	service.Organization.TenantRef = c.Tenant
+
+	created := new(CatalogService)
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Post(path).BodyJSON(service).Receive(created, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return created, nil
+}
+
+//UpdateCatalogService - To replace a catalog service
+func (c *APIClient) UpdateCatalogService(service *CatalogService) error {
+	path := fmt.Sprintf("/catalog-service/api/services/%s", service.ID)
+	service.Organization.TenantRef = c.Tenant
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(path).BodyJSON(service).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//DeleteCatalogService - To delete a catalog service, active services are deactivated first
+func (c *APIClient) DeleteCatalogService(serviceID string) error {
+	service, err := c.GetCatalogService(serviceID)
+	if err != nil {
+		return err
+	}
+	if service.Status == catalogServiceActive {
+		service.Status = catalogServiceInactive
+		if err := c.UpdateCatalogService(service); err != nil {
+			return err
+		}
+	}
+
+	path := fmt.Sprintf("/catalog-service/api/services/%s", serviceID)
+	apiError := new(APIError)
+	_, err = c.HTTPClient.New().Delete(path).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package vrealize
+
+import (
+	"encoding/json"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"net/http"
+	"testing"
+)
+
+func TestAPIClient_CatalogService(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://localhost/catalog-service/api/services",
+		func(req *http.Request) (*http.Response, error) {
+			service := new(CatalogService)
+			if err := json.NewDecoder(req.Body).Decode(service); err != nil {
+				return nil, err
+			}
+			if service.Organization.TenantRef != "vsphere.local" {
+				return httpmock.NewStringResponse(400, `{"errors":[{"code":20101,"message":"Tenant is required"}]}`), nil
+			}
+			return httpmock.NewStringResponse(201, `{"id":"2d7ec5a8-c2a6-4a4c-8ac5-6a2a1e3d3fa6","name":"Linux Machines","description":"","status":"ACTIVE","version":0,"organization":{"tenantRef":"vsphere.local","tenantLabel":"vsphere.local","subtenantRef":null,"subtenantLabel":null}}`), nil
+		})
+
+	created, err := client.CreateCatalogService(&CatalogService{Name: "Linux Machines", Status: catalogServiceActive})
+	if err != nil {
+		t.Fatalf("Failed to create catalog service %v", err)
+	}
+	if created.ID != "2d7ec5a8-c2a6-4a4c-8ac5-6a2a1e3d3fa6" {
+		t.Errorf("Expected catalog service 2d7ec5a8-c2a6-4a4c-8ac5-6a2a1e3d3fa6, got %v", created.ID)
+	}
+
+	httpmock.RegisterResponder("GET", "http://localhost/catalog-service/api/services/0b5d2c7e-6f0a-4bfa-9d43-1d1c9a3f4e22",
+		httpmock.NewStringResponder(404, `{"errors":[{"code":20117,"source":null,"message":"Service not found","systemMessage":"Service not found","moreInfoUrl":null}]}`))
+
+	if _, err := client.GetCatalogService("0b5d2c7e-6f0a-4bfa-9d43-1d1c9a3f4e22"); !isNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+func TestAPIClient_DeleteCatalogService(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	servicePath := "http://localhost/catalog-service/api/services/2d7ec5a8-c2a6-4a4c-8ac5-6a2a1e3d3fa6"
+	httpmock.RegisterResponder("GET", servicePath,
+		httpmock.NewStringResponder(200, `{"id":"2d7ec5a8-c2a6-4a4c-8ac5-6a2a1e3d3fa6","name":"Linux Machines","description":"","status":"ACTIVE","iconId":"cafe_default_icon_genericService","version":4,"organization":{"tenantRef":"vsphere.local","tenantLabel":"vsphere.local","subtenantRef":null,"subtenantLabel":null},"owner":{"tenantName":"vsphere.local","ref":"jason@corp.local","type":"USER","value":"Jason"},"supportTeam":null,"hours":{"startTime":"08:00","endTime":"18:00"},"changeWindow":null,"newDuration":null}`))
+
+	var deactivated, deleted bool
+	httpmock.RegisterResponder("PUT", servicePath,
+		func(req *http.Request) (*http.Response, error) {
+			service := new(CatalogService)
+			if err := json.NewDecoder(req.Body).Decode(service); err != nil {
+				return nil, err
+			}
+			deactivated = service.Status == catalogServiceInactive && service.Version == 4 &&
+				service.IconID == "cafe_default_icon_genericService"
+			return httpmock.NewStringResponse(200, ""), nil
+		})
+	httpmock.RegisterResponder("DELETE", servicePath,
+		func(req *http.Request) (*http.Response, error) {
+			deleted = deactivated
+			return httpmock.NewStringResponse(204, ""), nil
+		})
+
+	if err := client.DeleteCatalogService("2d7ec5a8-c2a6-4a4c-8ac5-6a2a1e3d3fa6"); err != nil {
+		t.Fatalf("Failed to delete catalog service %v", err)
+	}
+	if !deleted {
+		t.Errorf("Catalog service was not deactivated before it got deleted.")
+	}
+}
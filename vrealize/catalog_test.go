@@ -0,0 +1,168 @@
+package vrealize
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestAPIClient_readCatalogIDByName(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	//Two pages of catalog items, the filter is ignored so matching happens on the client side
+	pages := map[string]string{
+		"1": `[{"catalogItemId":"e5dd4fba-45ed-4943-b1fc-7f96239286be","name":"CentOS 6.3"},{"catalogItemId":"502efc1b-d5ce-4ef9-99ee-d4e2a741747c","name":"CentOS 6.3 - IPAM EXT"}]`,
+		"2": `[{"catalogItemId":"7bd6c0ea-a6b5-4cf7-8a2e-8a2bd7c1a0a9","name":"centos 6.3"}]`,
+	}
+	httpmock.RegisterResponder("GET", "http://localhost/catalog-service/api/consumer/entitledCatalogItemViews",
+		func(req *http.Request) (*http.Response, error) {
+			page := req.URL.Query().Get("page")
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`{"links":[],"content":%s,"metadata":{"size":2,"totalElements":3,"totalPages":2,"number":%s,"offset":0}}`,
+				pages[page], page)), nil
+		})
+
+	catalogID, err := client.readCatalogIDByName("CentOS 6.3", catalogNameMatchExact)
+	if err != nil {
+		t.Errorf("Failed to read catalog id %v", err)
+	}
+	if catalogID != "e5dd4fba-45ed-4943-b1fc-7f96239286be" {
+		t.Errorf("Expected catalog id e5dd4fba-45ed-4943-b1fc-7f96239286be, got %v", catalogID)
+	}
+
+	_, err = client.readCatalogIDByName("CentOS 6.3", catalogNameMatchCaseInsensitive)
+	if err == nil {
+		t.Errorf("Ambiguous case insensitive catalog name did not fail.")
+	}
+
+	catalogID, err = client.readCatalogIDByName("IPAM EXT$", catalogNameMatchRegex)
+	if err != nil || catalogID != "502efc1b-d5ce-4ef9-99ee-d4e2a741747c" {
+		t.Errorf("Failed to read catalog id by expression %v %v", catalogID, err)
+	}
+
+	_, err = client.readCatalogIDByName("CentOS", catalogNameMatchExact)
+	if err == nil {
+		t.Errorf("Catalog name prefix matched a catalog item.")
+	}
+}
+
+func TestCatalogItemTemplate_componentNames(t *testing.T) {
+	template := &CatalogItemTemplate{Data: map[string]interface{}{
+		"_leaseDays":       nil,
+		"CentOS_6.3":       map[string]interface{}{},
+		"corp192168110024": map[string]interface{}{},
+	}}
+
+	components := template.componentNames()
+	if len(components) != 2 || components[0] != "CentOS_6.3" || components[1] != "corp192168110024" {
+		t.Errorf("Unexpected component names %v", components)
+	}
+}
+
+func TestFlattenTemplateDefaults(t *testing.T) {
+	template := new(CatalogItemTemplate)
+	err := json.Unmarshal([]byte(`{"data":{"CentOS_6.3":{"componentTypeId":"com.vmware.csp.component.cafe.composition","classId":"Blueprint.Component.Declaration","data":{"_allocation":{"componentTypeId":"com.vmware.csp.iaas.blueprint.service","classId":"Infrastructure.Compute.Machine.Allocation","data":{"machines":[],"description":"nested"}},"_security":{"data":{"machines":"sibling","security_zone":"dmz"}},"cpu":1,"memory":512,"description":"Basic IaaS CentOS Machine","reservation_policy":null,"disks":[{"data":{"capacity":3}}]}}}}`), template)
+	if err != nil {
+		t.Fatalf("Failed to parse template %v", err)
+	}
+
+	defaults := make(map[string]interface{})
+	flattenTemplateDefaults(defaults, "CentOS_6.3", template.Data["CentOS_6.3"].(map[string]interface{})["data"].(map[string]interface{}))
+
+	expected := map[string]string{
+		"CentOS_6.3.cpu":                "1",
+		"CentOS_6.3.memory":             "512",
+		"CentOS_6.3.description":        "Basic IaaS CentOS Machine",
+		"CentOS_6.3.reservation_policy": "",
+		"CentOS_6.3.disks":              `[{"data":{"capacity":3}}]`,
+		"CentOS_6.3.machines":           "[]",
+		"CentOS_6.3.security_zone":      "dmz",
+	}
+	for key, value := range expected {
+		if defaults[key] != value {
+			t.Errorf("Expected %s to be %q, got %q", key, value, defaults[key])
+		}
+	}
+}
+
+func TestCatalogItemFilter_matches(t *testing.T) {
+	catalogItem := CatalogItemView{
+		CatalogItemID: "e5dd4fba-45ed-4943-b1fc-7f96239286be",
+		Name:          "CentOS 6.3",
+		ServiceRef:    Reference{ID: "2d7ec5a8-c2a6-4a4c-8ac5-6a2a1e3d3fa6", Label: "Linux Machines"},
+		CatalogItemTypeRef: Reference{ID: "com.vmware.csp.component.cafe.composition.blueprint",
+			Label: "Composite Blueprint"},
+		EntitledOrganizations: []EntitledOrganization{
+			{SubtenantRef: "53619006-56bb-4788-9723-9eab79752cc1", SubtenantLabel: "Content"},
+		},
+	}
+
+	filters := map[string]catalogItemFilter{
+		"no filter":      {},
+		"name":           {nameRegex: regexp.MustCompile("^CentOS")},
+		"service":        {serviceName: "linux machines"},
+		"type label":     {itemType: "Composite Blueprint"},
+		"type id":        {itemType: "com.vmware.csp.component.cafe.composition.blueprint"},
+		"business group": {businessGroupID: "53619006-56bb-4788-9723-9eab79752cc1", businessGroupName: "content"},
+	}
+	for description, filter := range filters {
+		if !filter.matches(catalogItem) {
+			t.Errorf("Catalog item does not match filter %s", description)
+		}
+	}
+
+	filters = map[string]catalogItemFilter{
+		"name":           {nameRegex: regexp.MustCompile("^Windows")},
+		"service":        {serviceName: "Windows Machines"},
+		"type":           {itemType: "XaaS Blueprint"},
+		"business group": {businessGroupName: "Finance"},
+	}
+	for description, filter := range filters {
+		if filter.matches(catalogItem) {
+			t.Errorf("Catalog item matches filter %s", description)
+		}
+	}
+}
+
+func TestAPIClient_CatalogItemDefinition(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost/catalog-service/api/catalogItems/e5dd4fba-45ed-4943-b1fc-7f96239286be",
+		httpmock.NewStringResponder(200, `{"@type":"CatalogItem","id":"e5dd4fba-45ed-4943-b1fc-7f96239286be","version":2,"name":"CentOS 6.3","description":"Basic IaaS CentOS Machine","status":"PUBLISHED","statusName":"Published","organization":{"tenantRef":"vsphere.local","tenantLabel":"vsphere.local","subtenantRef":null,"subtenantLabel":null},"providerBinding":{"bindingId":"vsphere.local!::!CentOS63","providerRef":{"id":"2fbaabc5-3a48-488a-9f2a-a42616345445","label":"Blueprint Service"}},"forms":{"catalogRequestInfoHidden":true},"iconId":"cafe_default_icon_genericCatalogItem","isNoteworthy":false,"serviceRef":{"id":"2d7ec5a8-c2a6-4a4c-8ac5-6a2a1e3d3fa6","label":"Linux Machines"},"outputResourceTypeRef":{"id":"composition.resource.type.deployment","label":"Deployment"}}`))
+	httpmock.RegisterResponder("GET", "http://localhost/catalog-service/api/catalogItems/7bd6c0ea-a6b5-4cf7-8a2e-8a2bd7c1a0a9",
+		httpmock.NewStringResponder(404, `{"errors":[{"code":20111,"source":null,"message":"Catalog item with id [7bd6c0ea-a6b5-4cf7-8a2e-8a2bd7c1a0a9] not found.","systemMessage":null,"moreInfoUrl":null}]}`))
+
+	catalogItem, err := client.GetCatalogItemDefinition("e5dd4fba-45ed-4943-b1fc-7f96239286be")
+	if err != nil {
+		t.Fatalf("Failed to get catalog item %v", err)
+	}
+	if catalogItem["status"] != catalogItemPublished || catalogItem["iconId"] != "cafe_default_icon_genericCatalogItem" {
+		t.Errorf("Unexpected catalog item %v", catalogItem)
+	}
+
+	if _, err := client.GetCatalogItemDefinition("7bd6c0ea-a6b5-4cf7-8a2e-8a2bd7c1a0a9"); !isNotFound(err) {
+		t.Errorf("Expected a not found error for a missing catalog item, got %v", err)
+	}
+
+	//The whole catalog item is sent back, including the fields not managed by terraform
+	httpmock.RegisterResponder("PUT", "http://localhost/catalog-service/api/catalogItems/e5dd4fba-45ed-4943-b1fc-7f96239286be",
+		func(req *http.Request) (*http.Response, error) {
+			document := make(map[string]interface{})
+			if err := json.NewDecoder(req.Body).Decode(&document); err != nil {
+				return nil, err
+			}
+			if document["status"] != catalogItemRetired || document["providerBinding"] == nil {
+				return httpmock.NewStringResponse(400, `{"errors":[{"code":20112,"message":"Invalid catalog item"}]}`), nil
+			}
+			return httpmock.NewStringResponse(200, ""), nil
+		})
+
+	catalogItem["status"] = catalogItemRetired
+	if err := client.UpdateCatalogItemDefinition("e5dd4fba-45ed-4943-b1fc-7f96239286be", catalogItem); err != nil {
+		t.Errorf("Failed to update catalog item %v", err)
+	}
+}
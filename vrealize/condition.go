@@ -0,0 +1,119 @@
+package vrealize
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+//conditionOperators - operators comparing a field with a value
+var conditionOperators = []string{
+	"equals",
+	"notEquals",
+	"greaterThan",
+	"greaterThanOrEquals",
+	"lessThan",
+	"lessThanOrEquals",
+	"contains",
+	"startsWith",
+	"endsWith",
+}
+
+//ExpressionCondition - This struct holds a comparison of a field with a value, as used by
+//the conditions of approval levels and the criteria of event subscriptions
+type ExpressionCondition struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+//conditionClause - To build the expression clause requiring all conditions
+func conditionClause(conditions []ExpressionCondition) map[string]interface{} {
+	var subClauses []interface{}
+	for _, condition := range conditions {
+		subClauses = append(subClauses, map[string]interface{}{
+			"type":         "expression",
+			"operator":     map[string]interface{}{"type": condition.Operator},
+			"leftOperand":  map[string]interface{}{"type": "path", "path": condition.Field},
+			"rightOperand": map[string]interface{}{"type": "constant", "value": literalValue("string", condition.Value)},
+		})
+	}
+	return map[string]interface{}{
+		"type":       "expression",
+		"operator":   map[string]interface{}{"type": "and"},
+		"subClauses": subClauses,
+	}
+}
+
+//expressionConditions - To read the conditions of an expression clause built by conditionClause
+func expressionConditions(clause map[string]interface{}) []ExpressionCondition {
+	subClauses, _ := clause["subClauses"].([]interface{})
+
+	var conditions []ExpressionCondition
+	for _, item := range subClauses {
+		subClause, _ := item.(map[string]interface{})
+		operator, _ := subClause["operator"].(map[string]interface{})
+		leftOperand, _ := subClause["leftOperand"].(map[string]interface{})
+		rightOperand, _ := subClause["rightOperand"].(map[string]interface{})
+		value, _ := rightOperand["value"].(map[string]interface{})
+
+		condition := ExpressionCondition{Value: fmt.Sprint(value["value"])}
+		condition.Operator, _ = operator["type"].(string)
+		condition.Field, _ = leftOperand["path"].(string)
+		conditions = append(conditions, condition)
+	}
+	return conditions
+}
+
+//conditionSchema - condition blocks, each comparing a field with a value
+func conditionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"field": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"operator": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(conditionOperators, false),
+				},
+				"value": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+			},
+		},
+	}
+}
+
+//expandConditions - To read the conditions from their configuration blocks
+func expandConditions(config []interface{}) []ExpressionCondition {
+	var conditions []ExpressionCondition
+	for _, item := range config {
+		condition := item.(map[string]interface{})
+		conditions = append(conditions, ExpressionCondition{
+			Field:    condition["field"].(string),
+			Operator: condition["operator"].(string),
+			Value:    condition["value"].(string),
+		})
+	}
+	return conditions
+}
+
+//flattenConditions - To convert the conditions of an expression clause into configuration blocks
+func flattenConditions(clause map[string]interface{}) []interface{} {
+	var conditions []interface{}
+	for _, condition := range expressionConditions(clause) {
+		conditions = append(conditions, map[string]interface{}{
+			"field":    condition.Field,
+			"operator": condition.Operator,
+			"value":    condition.Value,
+		})
+	}
+	return conditions
+}
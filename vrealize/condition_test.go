@@ -0,0 +1,31 @@
+package vrealize
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestExpressionConditions(t *testing.T) {
+	conditions := []ExpressionCondition{
+		{Field: "provider-Cafe.Shim.VirtualMachine.TotalStorageSize", Operator: "greaterThan", Value: "100"},
+		{Field: "provider-VirtualMachine.CPU.Count", Operator: "greaterThanOrEquals", Value: "4"},
+	}
+
+	//Read the clause back the way it is returned by the API
+	data, err := json.Marshal(conditionClause(conditions))
+	if err != nil {
+		t.Fatalf("Failed to encode condition clause %v", err)
+	}
+	var clause map[string]interface{}
+	if err := json.Unmarshal(data, &clause); err != nil {
+		t.Fatalf("Failed to decode condition clause %v", err)
+	}
+
+	if read := expressionConditions(clause); !reflect.DeepEqual(read, conditions) {
+		t.Errorf("Expected conditions %v, got %v", conditions, read)
+	}
+	if read := expressionConditions(nil); len(read) != 0 {
+		t.Errorf("Expected no conditions without a clause, got %v", read)
+	}
+}
@@ -0,0 +1,89 @@
+package vrealize
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+//DataSourceBusinessGroup - use to set business group data source fields
+func DataSourceBusinessGroup() *schema.Resource {
+	return &schema.Resource{
+		Read:   readBusinessGroupDataSource,
+		Schema: businessGroupDataSourceSchema(),
+	}
+}
+
+//businessGroupDataSourceSchema - The business group is looked up either by name or by id
+func businessGroupDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ConflictsWith: []string{"businessgroup_id"},
+		},
+		"businessgroup_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ConflictsWith: []string{"name"},
+		},
+		"subtenant_ref": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"managers": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"members": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+//Function use - To read a business group with its managers and members
+//Terraform call - terraform refresh
+func readBusinessGroupDataSource(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	var businessGroup *BusinessGroup
+	var err error
+	if businessGroupID, ok := d.GetOk("businessgroup_id"); ok {
+		businessGroup, err = client.GetBusinessGroup(businessGroupID.(string))
+	} else if name, ok := d.GetOk("name"); ok {
+		businessGroup, err = client.GetBusinessGroupByName(name.(string))
+	} else {
+		return fmt.Errorf("Either name or businessgroup_id should be present in given configuration")
+	}
+	if err != nil {
+		return err
+	}
+
+	managers, err := client.GetBusinessGroupRolePrincipals(businessGroup.ID, businessGroupManagerRole)
+	if err != nil {
+		return err
+	}
+	members, err := client.GetBusinessGroupMembers(businessGroup.ID)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(businessGroup.ID)
+	d.Set("businessgroup_id", businessGroup.ID)
+	d.Set("subtenant_ref", businessGroup.ID)
+	d.Set("name", businessGroup.Name)
+	d.Set("description", businessGroup.Description)
+	d.Set("managers", principalNames(managers))
+	d.Set("members", principalNames(members))
+	return nil
+}
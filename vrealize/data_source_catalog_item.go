@@ -0,0 +1,123 @@
+package vrealize
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+//DataSourceCatalogItem - use to set catalog item data source fields
+func DataSourceCatalogItem() *schema.Resource {
+	return &schema.Resource{
+		Read:   readCatalogItemDataSource,
+		Schema: catalogItemDataSourceSchema(),
+	}
+}
+
+//catalogItemDataSourceSchema - The catalog item is looked up either by name or by id
+func catalogItemDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ConflictsWith: []string{"catalog_item_id"},
+		},
+		"name_match": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      catalogNameMatchExact,
+			ValidateFunc: validation.StringInSlice(catalogNameMatchTypes, false),
+		},
+		"catalog_item_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ConflictsWith: []string{"name"},
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"service_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"service_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"business_group_ids": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"business_group_names": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"version": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"component_names": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+//Function use - To read an entitled catalog item by name or id
+//Terraform call - terraform refresh
+func readCatalogItemDataSource(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	catalogID := d.Get("catalog_item_id").(string)
+	if len(catalogID) == 0 {
+		if len(d.Get("name").(string)) == 0 {
+			return fmt.Errorf("Either name or catalog_item_id should be present in given configuration")
+		}
+		var err error
+		catalogID, err = client.readCatalogIDByName(d.Get("name").(string), d.Get("name_match").(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	catalogItem, err := client.GetEntitledCatalogItem(catalogID)
+	if err != nil {
+		return fmt.Errorf("Catalog item %s failed to load: %v", catalogID, err)
+	}
+
+	template, err := client.GetCatalogItem(catalogID)
+	if err != nil {
+		return fmt.Errorf("Catalog item %s template failed to load: %v", catalogID, err)
+	}
+
+	var businessGroupIDs, businessGroupNames []string
+	for _, organization := range catalogItem.EntitledOrganizations {
+		businessGroupIDs = append(businessGroupIDs, organization.SubtenantRef)
+		businessGroupNames = append(businessGroupNames, organization.SubtenantLabel)
+	}
+
+	d.SetId(catalogID)
+	d.Set("catalog_item_id", catalogID)
+	d.Set("name", catalogItem.CatalogItem.Name)
+	d.Set("description", catalogItem.CatalogItem.Description)
+	d.Set("service_id", catalogItem.CatalogItem.ServiceRef.ID)
+	d.Set("service_name", catalogItem.CatalogItem.ServiceRef.Label)
+	d.Set("business_group_ids", businessGroupIDs)
+	d.Set("business_group_names", businessGroupNames)
+	d.Set("version", catalogItem.CatalogItem.Version)
+	d.Set("status", catalogItem.CatalogItem.Status)
+	d.Set("component_names", template.componentNames())
+	return nil
+}
@@ -0,0 +1,172 @@
+package vrealize
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+//DataSourceCatalogItemTemplate - use to set catalog item request template data source fields
+func DataSourceCatalogItemTemplate() *schema.Resource {
+	return &schema.Resource{
+		Read:   readCatalogItemTemplateDataSource,
+		Schema: catalogItemTemplateDataSourceSchema(),
+	}
+}
+
+//catalogItemTemplateDataSourceSchema - The defaults use the keys of the resource_configuration
+//and deployment_configuration arguments of vra7_resource
+func catalogItemTemplateDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ConflictsWith: []string{"catalog_item_id"},
+		},
+		"name_match": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      catalogNameMatchExact,
+			ValidateFunc: validation.StringInSlice(catalogNameMatchTypes, false),
+		},
+		"catalog_item_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ConflictsWith: []string{"name"},
+		},
+		"business_group_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"component_names": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"resource_defaults": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     schema.TypeString,
+		},
+		"deployment_defaults": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     schema.TypeString,
+		},
+		"template_json": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+//Function use - To read the request template of an entitled catalog item
+//Terraform call - terraform refresh
+func readCatalogItemTemplateDataSource(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	catalogID := d.Get("catalog_item_id").(string)
+	if len(catalogID) == 0 {
+		if len(d.Get("name").(string)) == 0 {
+			return fmt.Errorf("Either name or catalog_item_id should be present in given configuration")
+		}
+		var err error
+		catalogID, err = client.readCatalogIDByName(d.Get("name").(string), d.Get("name_match").(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	template, err := client.GetCatalogItem(catalogID)
+	if err != nil {
+		return fmt.Errorf("Catalog item %s template failed to load: %v", catalogID, err)
+	}
+
+	templateJSON, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("Catalog item %s template could not be encoded: %v", catalogID, err)
+	}
+
+	resourceDefaults := make(map[string]interface{})
+	deploymentDefaults := make(map[string]interface{})
+	for field, value := range template.Data {
+		if component, ok := value.(map[string]interface{}); ok {
+			componentData, _ := component["data"].(map[string]interface{})
+			flattenTemplateDefaults(resourceDefaults, field, componentData)
+		} else {
+			deploymentDefaults[field] = templateValueString(value)
+		}
+	}
+
+	d.SetId(catalogID)
+	d.Set("catalog_item_id", catalogID)
+	if len(d.Get("name").(string)) == 0 {
+		catalogName, err := client.readCatalogNameByID(catalogID)
+		if err != nil {
+			return err
+		}
+		d.Set("name", catalogName.(string))
+	}
+	d.Set("business_group_id", template.BusinessGroupID)
+	d.Set("component_names", template.componentNames())
+	d.Set("resource_defaults", resourceDefaults)
+	d.Set("deployment_defaults", deploymentDefaults)
+	d.Set("template_json", string(templateJSON))
+	return nil
+}
+
+//flattenTemplateDefaults - To collect the default values of a component as component.field keys.
+//Like changeTemplateValue nested fields are addressed by their own name, where a field
+//closer to the component wins over a nested field with the same name. Among sibling nested
+//fields the one whose name sorts first wins, so the defaults do not depend on map order.
+func flattenTemplateDefaults(defaults map[string]interface{}, component string, data map[string]interface{}) {
+	var nestedFields []string
+	for field, value := range data {
+		if reflect.ValueOf(value).Kind() == reflect.Map {
+			nestedFields = append(nestedFields, field)
+			continue
+		}
+		defaults[component+"."+field] = templateValueString(value)
+	}
+
+	sort.Strings(nestedFields)
+	for _, field := range nestedFields {
+		nestedComponent, _ := data[field].(map[string]interface{})
+		nestedDefaults := make(map[string]interface{})
+		nestedData, ok := nestedComponent["data"].(map[string]interface{})
+		if !ok {
+			nestedData = nestedComponent
+		}
+		flattenTemplateDefaults(nestedDefaults, component, nestedData)
+		for key, value := range nestedDefaults {
+			if _, exists := defaults[key]; !exists {
+				defaults[key] = value
+			}
+		}
+	}
+}
+
+//templateValueString - To represent a template value in a string map,
+//lists are encoded as JSON and null values are empty
+func templateValueString(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value.(string)
+	case float64:
+		return strconv.FormatFloat(value.(float64), 'f', -1, 64)
+	case []interface{}:
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,138 @@
+package vrealize
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+//DataSourceCatalogItems - use to set catalog item list data source fields
+func DataSourceCatalogItems() *schema.Resource {
+	return &schema.Resource{
+		Read:   readCatalogItemsDataSource,
+		Schema: catalogItemsDataSourceSchema(),
+	}
+}
+
+//catalogItemsDataSourceSchema - All filters are optional and combined,
+//without any filter every entitled catalog item is listed
+func catalogItemsDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name_regex": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"service_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"business_group_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"business_group_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"item_type": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"ids": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"names": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"catalog_items": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"catalog_item_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"description": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"service_name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"item_type": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"business_group_ids": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+	}
+}
+
+//Function use - To list the entitled catalog items matching the filters
+//Terraform call - terraform refresh
+func readCatalogItemsDataSource(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	filter := catalogItemFilter{
+		serviceName:       d.Get("service_name").(string),
+		businessGroupID:   d.Get("business_group_id").(string),
+		businessGroupName: d.Get("business_group_name").(string),
+		itemType:          d.Get("item_type").(string),
+	}
+	if nameRegex, ok := d.GetOk("name_regex"); ok {
+		expression, err := regexp.Compile(nameRegex.(string))
+		if err != nil {
+			return fmt.Errorf("Invalid name_regex %s: %v", nameRegex.(string), err)
+		}
+		filter.nameRegex = expression
+	}
+
+	catalogItems, err := client.listCatalogItemViews(filter)
+	if err != nil {
+		return err
+	}
+
+	var ids, names []string
+	var items []map[string]interface{}
+	for _, catalogItem := range catalogItems {
+		var businessGroupIDs []string
+		for _, organization := range catalogItem.EntitledOrganizations {
+			businessGroupIDs = append(businessGroupIDs, organization.SubtenantRef)
+		}
+		ids = append(ids, catalogItem.CatalogItemID)
+		names = append(names, catalogItem.Name)
+		items = append(items, map[string]interface{}{
+			"catalog_item_id":    catalogItem.CatalogItemID,
+			"name":               catalogItem.Name,
+			"description":        catalogItem.Description,
+			"service_name":       catalogItem.ServiceRef.Label,
+			"item_type":          catalogItem.CatalogItemTypeRef.Label,
+			"business_group_ids": businessGroupIDs,
+		})
+	}
+
+	d.SetId(time.Now().UTC().String())
+	d.Set("ids", ids)
+	d.Set("names", names)
+	d.Set("catalog_items", items)
+	return nil
+}
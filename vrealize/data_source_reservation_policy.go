@@ -0,0 +1,74 @@
+package vrealize
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+//DataSourceReservationPolicy - use to set reservation policy data source fields
+func DataSourceReservationPolicy() *schema.Resource {
+	return reservationPolicyDataSource(computeReservationPolicyType)
+}
+
+//DataSourceStorageReservationPolicy - use to set storage reservation policy data source fields
+func DataSourceStorageReservationPolicy() *schema.Resource {
+	return reservationPolicyDataSource(storageReservationPolicyType)
+}
+
+//reservationPolicyDataSource - The policy is looked up either by name or by id
+func reservationPolicyDataSource(policyTypeID string) *schema.Resource {
+	return &schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return readReservationPolicyDataSource(d, meta, policyTypeID)
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"policy_id"},
+			},
+			"policy_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+//Function use - To read a reservation policy of the given type
+//Terraform call - terraform refresh
+func readReservationPolicyDataSource(d *schema.ResourceData, meta interface{}, policyTypeID string) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	var policy *ReservationPolicy
+	var err error
+	if policyID, ok := d.GetOk("policy_id"); ok {
+		policy, err = client.GetReservationPolicy(policyID.(string))
+		if err == nil && policy.ReservationPolicyTypeID != policyTypeID {
+			err = fmt.Errorf("reservation policy %s is of type %s, expected %s",
+				policy.ID, policy.ReservationPolicyTypeID, policyTypeID)
+		}
+	} else if name, ok := d.GetOk("name"); ok {
+		policy, err = client.GetReservationPolicyByName(policyTypeID, name.(string))
+	} else {
+		return fmt.Errorf("Either name or policy_id should be present in given configuration")
+	}
+	if err != nil {
+		return err
+	}
+
+	d.SetId(policy.ID)
+	d.Set("policy_id", policy.ID)
+	d.Set("name", policy.Name)
+	d.Set("description", policy.Description)
+	return nil
+}
@@ -0,0 +1,140 @@
+package vrealize
+
+import (
+	"fmt"
+)
+
+//Entitlement - This struct holds an entitlement of the catalog service
+type Entitlement struct {
+	ID                         string                      `json:"id,omitempty"`
+	Name                       string                      `json:"name"`
+	Description                string                      `json:"description"`
+	Status                     string                      `json:"status"`
+	Organization               EntitledOrganization        `json:"organization"`
+	Principals                 []EntitlementPrincipal      `json:"principals"`
+	EntitledServices           []EntitledService           `json:"entitledServices"`
+	EntitledCatalogItems       []EntitledCatalogItem       `json:"entitledCatalogItems"`
+	EntitledResourceOperations []EntitledResourceOperation `json:"entitledResourceOperations"`
+	AllUsers                   bool                        `json:"allUsers"`
+	LocalScopeForActions       bool                        `json:"localScopeForActions"`
+	Version                    int                         `json:"version,omitempty"`
+}
+
+//EntitlementPrincipal - This struct holds a user or group of an entitlement or catalog service
+type EntitlementPrincipal struct {
+	TenantName string `json:"tenantName"`
+	Ref        string `json:"ref"`
+	Type       string `json:"type"`
+	Value      string `json:"value,omitempty"`
+}
+
+//EntitledService - This struct holds a service of an entitlement
+type EntitledService struct {
+	ServiceRef       Reference `json:"serviceRef"`
+	ApprovalPolicyID string    `json:"approvalPolicyId,omitempty"`
+	Active           bool      `json:"active"`
+}
+
+//EntitledCatalogItem - This struct holds a catalog item of an entitlement
+type EntitledCatalogItem struct {
+	CatalogItemRef         Reference `json:"catalogItemRef"`
+	ApprovalPolicyID       string    `json:"approvalPolicyId,omitempty"`
+	Active                 bool      `json:"active"`
+	CatalogItemRequestable bool      `json:"catalogItemRequestable"`
+}
+
+//EntitledResourceOperation - This struct holds a resource action of an entitlement
+type EntitledResourceOperation struct {
+	ResourceOperationRef  Reference `json:"resourceOperationRef"`
+	TargetResourceTypeRef Reference `json:"targetResourceTypeRef"`
+	ResourceOperationType string    `json:"resourceOperationType"`
+	ExternalID            string    `json:"externalId,omitempty"`
+	ApprovalPolicyID      string    `json:"approvalPolicyId,omitempty"`
+	Active                bool      `json:"active"`
+}
+
+//GetEntitlement - To read an entitlement by its id
+func (c *APIClient) GetEntitlement(entitlementID string) (*Entitlement, error) {
+	path := fmt.Sprintf("/catalog-service/api/entitlements/%s", entitlementID)
+
+	entitlement := new(Entitlement)
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Get(path).Receive(entitlement, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, NotFoundError{"entitlement", entitlementID}
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return entitlement, nil
+}
+
+//CreateEntitlement - To create an entitlement for the tenant of the client
+func (c *APIClient) CreateEntitlement(entitlement *Entitlement) (*Entitlement, error) {
+	path := "/catalog-service/api/entitlements"
+	entitlement.Organization.TenantRef = c.Tenant
+
+	created := new(Entitlement)
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Post(path).BodyJSON(entitlement).Receive(created, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return created, nil
+}
+
+//UpdateEntitlement - To replace an entitlement
+func (c *APIClient) UpdateEntitlement(entitlement *Entitlement) error {
+	path := fmt.Sprintf("/catalog-service/api/entitlements/%s", entitlement.ID)
+	entitlement.Organization.TenantRef = c.Tenant
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(path).BodyJSON(entitlement).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//DeleteEntitlement - To delete an entitlement, active entitlements are deactivated first
+func (c *APIClient) DeleteEntitlement(entitlementID string) error {
+	entitlement, err := c.GetEntitlement(entitlementID)
+	if err != nil {
+		return err
+	}
+	if entitlement.Status == entitlementActive {
+		entitlement.Status = entitlementInactive
+		if err := c.UpdateEntitlement(entitlement); err != nil {
+			return err
+		}
+	}
+
+	path := fmt.Sprintf("/catalog-service/api/entitlements/%s", entitlementID)
+	apiError := new(APIError)
+	_, err = c.HTTPClient.New().Delete(path).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
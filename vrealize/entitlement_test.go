@@ -0,0 +1,48 @@
+package vrealize
+
+import (
+	"encoding/json"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"net/http"
+	"testing"
+)
+
+func TestAPIClient_DeleteEntitlement(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	entitlementPath := "http://localhost/catalog-service/api/entitlements/e2a6f44b-9c3c-4b5e-8a4f-5c1d0e0e6b71"
+	httpmock.RegisterResponder("GET", entitlementPath,
+		httpmock.NewStringResponder(200, `{"id":"e2a6f44b-9c3c-4b5e-8a4f-5c1d0e0e6b71","name":"Content entitlement","description":"","status":"ACTIVE","organization":{"tenantRef":"vsphere.local","tenantLabel":"vsphere.local","subtenantRef":"53619006-56bb-4788-9723-9eab79752cc1","subtenantLabel":"Content"},"principals":[{"tenantName":"vsphere.local","ref":"devuser@corp.local","type":"USER","value":"Dev User"}],"entitledCatalogItems":[],"entitledResourceOperations":[],"entitledServices":[],"allUsers":false,"localScopeForActions":true,"version":3}`))
+
+	var deactivated, deleted bool
+	httpmock.RegisterResponder("PUT", entitlementPath,
+		func(req *http.Request) (*http.Response, error) {
+			entitlement := new(Entitlement)
+			if err := json.NewDecoder(req.Body).Decode(entitlement); err != nil {
+				return nil, err
+			}
+			deactivated = entitlement.Status == entitlementInactive && entitlement.Version == 3
+			return httpmock.NewStringResponse(200, ""), nil
+		})
+	httpmock.RegisterResponder("DELETE", entitlementPath,
+		func(req *http.Request) (*http.Response, error) {
+			deleted = deactivated
+			return httpmock.NewStringResponse(204, ""), nil
+		})
+
+	if err := client.DeleteEntitlement("e2a6f44b-9c3c-4b5e-8a4f-5c1d0e0e6b71"); err != nil {
+		t.Fatalf("Failed to delete entitlement %v", err)
+	}
+	if !deleted {
+		t.Errorf("Entitlement was not deactivated before it got deleted.")
+	}
+
+	httpmock.RegisterResponder("GET", "http://localhost/catalog-service/api/entitlements/0b5d2c7e-6f0a-4bfa-9d43-1d1c9a3f4e22",
+		httpmock.NewStringResponder(404, `{"errors":[{"code":20116,"source":null,"message":"Entitlement not found","systemMessage":"Entitlement not found","moreInfoUrl":null}]}`))
+
+	_, err := client.GetEntitlement("0b5d2c7e-6f0a-4bfa-9d43-1d1c9a3f4e22")
+	if !isNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package vrealize
+
+import (
+	"fmt"
+)
+
+//Event subscription states and the subscriber type running a vRO workflow
+const (
+	eventSubscriptionPublished = "PUBLISHED"
+	eventSubscriptionDraft     = "DRAFT"
+	workflowSubscriptionType   = "WORKFLOW"
+)
+
+//EventSubscription - This struct holds an event broker subscription running a workflow on an event topic
+type EventSubscription struct {
+	ID           string                 `json:"id,omitempty"`
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description"`
+	Type         string                 `json:"type"`
+	EventTopicID string                 `json:"eventTopicId"`
+	Blocking     bool                   `json:"blocking"`
+	Priority     int                    `json:"priority"`
+	Timeout      int                    `json:"timeout"`
+	Status       string                 `json:"status"`
+	Criteria     map[string]interface{} `json:"criteria,omitempty"`
+	Definition   SubscriptionDefinition `json:"definition"`
+}
+
+//SubscriptionDefinition - This struct holds the workflow bound to a subscription
+type SubscriptionDefinition struct {
+	WorkflowID string `json:"workflowId"`
+}
+
+//eventSubscriptionPath - To build the path of the subscriptions of the tenant of the client
+func (c *APIClient) eventSubscriptionPath(subscriptionID string) string {
+	path := fmt.Sprintf("/advanced-designer-service/api/tenants/%s/event-broker/subscriptions", c.Tenant)
+	if len(subscriptionID) > 0 {
+		path = fmt.Sprintf("%s/%s", path, subscriptionID)
+	}
+	return path
+}
+
+//GetEventSubscription - To read an event broker subscription by its id
+func (c *APIClient) GetEventSubscription(subscriptionID string) (*EventSubscription, error) {
+	subscription := new(EventSubscription)
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Get(c.eventSubscriptionPath(subscriptionID)).Receive(subscription, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, NotFoundError{"event subscription", subscriptionID}
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return subscription, nil
+}
+
+//CreateEventSubscription - To create an event broker subscription
+func (c *APIClient) CreateEventSubscription(subscription *EventSubscription) (*EventSubscription, error) {
+	created := new(EventSubscription)
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Post(c.eventSubscriptionPath("")).
+		BodyJSON(subscription).Receive(created, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return created, nil
+}
+
+//UpdateEventSubscription - To replace an event broker subscription, including its status
+func (c *APIClient) UpdateEventSubscription(subscription *EventSubscription) error {
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(c.eventSubscriptionPath(subscription.ID)).
+		BodyJSON(subscription).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//DeleteEventSubscription - To delete an event broker subscription
+func (c *APIClient) DeleteEventSubscription(subscriptionID string) error {
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Delete(c.eventSubscriptionPath(subscriptionID)).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == 404 {
+		return NotFoundError{"event subscription", subscriptionID}
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package vrealize
+
+import (
+	"encoding/json"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"net/http"
+	"testing"
+)
+
+func TestAPIClient_EventSubscription(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost/advanced-designer-service/api/tenants/vsphere.local/event-broker/subscriptions/4c6e1f2a-8b3d-4e5f-9a7c-2d1b0e3f4a5c",
+		httpmock.NewStringResponder(200, `{"id":"4c6e1f2a-8b3d-4e5f-9a7c-2d1b0e3f4a5c","type":"WORKFLOW","eventTopicId":"com.vmware.csp.iaas.blueprint.service.machine.lifecycle.provision","name":"Register in CMDB","description":"","blocking":true,"priority":10,"timeout":30,"status":"PUBLISHED","criteria":{"type":"expression","operator":{"type":"and"},"subClauses":[{"type":"expression","operator":{"type":"equals"},"leftOperand":{"type":"path","path":"data~lifecycleState~state"},"rightOperand":{"type":"constant","value":{"type":"string","value":"VMPSMasterWorkflow32.MachineProvisioned"}}}]},"definition":{"workflowId":"b1d2c3e4-f5a6-4b7c-8d9e-0f1a2b3c4d5e"}}`))
+
+	subscription, err := client.GetEventSubscription("4c6e1f2a-8b3d-4e5f-9a7c-2d1b0e3f4a5c")
+	if err != nil {
+		t.Fatalf("Failed to get event subscription %v", err)
+	}
+	if !subscription.Blocking || subscription.Definition.WorkflowID != "b1d2c3e4-f5a6-4b7c-8d9e-0f1a2b3c4d5e" {
+		t.Errorf("Unexpected subscription settings %v", subscription)
+	}
+	conditions := expressionConditions(subscription.Criteria)
+	if len(conditions) != 1 || conditions[0].Value != "VMPSMasterWorkflow32.MachineProvisioned" {
+		t.Errorf("Unexpected conditions %v", conditions)
+	}
+
+	httpmock.RegisterResponder("PUT", "http://localhost/advanced-designer-service/api/tenants/vsphere.local/event-broker/subscriptions/4c6e1f2a-8b3d-4e5f-9a7c-2d1b0e3f4a5c",
+		func(req *http.Request) (*http.Response, error) {
+			document := make(map[string]interface{})
+			if err := json.NewDecoder(req.Body).Decode(&document); err != nil {
+				return nil, err
+			}
+			if document["status"] != eventSubscriptionDraft {
+				return httpmock.NewStringResponse(400, `{"errors":[{"code":10101,"message":"Unexpected status"}]}`), nil
+			}
+			return httpmock.NewStringResponse(200, ""), nil
+		})
+
+	subscription.Status = eventSubscriptionDraft
+	if err := client.UpdateEventSubscription(subscription); err != nil {
+		t.Errorf("Failed to unpublish event subscription %v", err)
+	}
+
+	httpmock.RegisterResponder("DELETE", "http://localhost/advanced-designer-service/api/tenants/vsphere.local/event-broker/subscriptions/4c6e1f2a-8b3d-4e5f-9a7c-2d1b0e3f4a5c",
+		httpmock.NewStringResponder(404, `{"errors":[{"code":20116,"message":"Subscription not found"}]}`))
+
+	if err := client.DeleteEventSubscription("4c6e1f2a-8b3d-4e5f-9a7c-2d1b0e3f4a5c"); !isNotFound(err) {
+		t.Errorf("Expected a not found error for a deleted subscription, got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package vrealize
+
+import (
+	"fmt"
+	"path"
+)
+
+//networkProfileTypes - network profile types and the @type of their documents
+var networkProfileTypes = map[string]string{
+	"EXTERNAL": "ExternalNetworkProfile",
+	"NAT":      "NATNetworkProfile",
+	"ROUTED":   "RoutedNetworkProfile",
+}
+
+//NetworkProfile - This struct holds an external, NAT or routed network profile
+type NetworkProfile struct {
+	Type                 string         `json:"@type"`
+	ID                   string         `json:"id,omitempty"`
+	Name                 string         `json:"name"`
+	Description          string         `json:"description"`
+	ProfileType          string         `json:"profileType"`
+	SubnetMask           string         `json:"subnetMask"`
+	GatewayAddress       string         `json:"gatewayAddress,omitempty"`
+	PrimaryDNSAddress    string         `json:"primaryDnsAddress,omitempty"`
+	SecondaryDNSAddress  string         `json:"secondaryDnsAddress,omitempty"`
+	DNSSuffix            string         `json:"dnsSuffix,omitempty"`
+	DNSSearchSuffix      string         `json:"dnsSearchSuffix,omitempty"`
+	ExternalNetProfileID string         `json:"externalNetProfileId,omitempty"`
+	NatType              string         `json:"natType,omitempty"`
+	RangeSubnetMask      string         `json:"rangeSubnetMask,omitempty"`
+	BaseIP               string         `json:"baseIP,omitempty"`
+	DefinedRanges        []NetworkRange `json:"definedRanges"`
+}
+
+//NetworkRange - This struct holds an IP range of a network profile
+type NetworkRange struct {
+	ID               string `json:"id,omitempty"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	BeginIPv4Address string `json:"beginIPv4Address"`
+	EndIPv4Address   string `json:"endIPv4Address"`
+}
+
+//GetNetworkProfile - To read a network profile by its id
+func (c *APIClient) GetNetworkProfile(profileID string) (*NetworkProfile, error) {
+	path := fmt.Sprintf("/iaas-proxy-provider/api/network/profiles/%s", profileID)
+
+	profile := new(NetworkProfile)
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Get(path).Receive(profile, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, NotFoundError{"network profile", profileID}
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return profile, nil
+}
+
+//CreateNetworkProfile - To create a network profile and return its id
+func (c *APIClient) CreateNetworkProfile(profile *NetworkProfile) (string, error) {
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Post("/iaas-proxy-provider/api/network/profiles").
+		BodyJSON(profile).Receive(nil, apiError)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !apiError.isEmpty() {
+		return "", apiError
+	}
+
+	if resp.StatusCode != 201 {
+		return "", fmt.Errorf("network profile creation failed with status %s", resp.Status)
+	}
+
+	//The created profile is referred by the location header
+	return path.Base(resp.Header.Get("Location")), nil
+}
+
+//UpdateNetworkProfile - To replace a network profile
+func (c *APIClient) UpdateNetworkProfile(profile *NetworkProfile) error {
+	path := fmt.Sprintf("/iaas-proxy-provider/api/network/profiles/%s", profile.ID)
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(path).BodyJSON(profile).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//DeleteNetworkProfile - To delete a network profile
+func (c *APIClient) DeleteNetworkProfile(profileID string) error {
+	path := fmt.Sprintf("/iaas-proxy-provider/api/network/profiles/%s", profileID)
+
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Delete(path).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == 404 {
+		return NotFoundError{"network profile", profileID}
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package vrealize
+
+import (
+	"encoding/json"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"net/http"
+	"testing"
+)
+
+func TestAPIClient_NetworkProfile(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost/iaas-proxy-provider/api/network/profiles/9e1f7a55-3c2b-4d8e-b6a4-1f0e2d3c4b5a",
+		httpmock.NewStringResponder(200, `{"@type":"NATNetworkProfile","id":"9e1f7a55-3c2b-4d8e-b6a4-1f0e2d3c4b5a","name":"Content-NAT","description":"","createdDate":"2018-01-10T11:40:10.000Z","lastModifiedDate":"2018-01-10T11:40:10.000Z","isHidden":false,"definedRanges":[{"id":"0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d","name":"Range1","description":"","beginIPv4Address":"192.168.10.10","endIPv4Address":"192.168.10.50","state":"UNALLOCATED","createdDate":"2018-01-10T11:40:10.000Z","lastModifiedDate":"2018-01-10T11:40:10.000Z","definedAddresses":[]}],"profileType":"NAT","subnetMask":"255.255.255.0","gatewayAddress":"192.168.10.1","primaryDnsAddress":"10.0.0.2","externalNetProfileId":"5d4c3b2a-1f0e-4d9c-8b7a-6f5e4d3c2b1a","natType":"ONETOMANY"}`))
+
+	profile, err := client.GetNetworkProfile("9e1f7a55-3c2b-4d8e-b6a4-1f0e2d3c4b5a")
+	if err != nil {
+		t.Fatalf("Failed to get network profile %v", err)
+	}
+	if profile.NatType != "ONETOMANY" || profile.ExternalNetProfileID != "5d4c3b2a-1f0e-4d9c-8b7a-6f5e4d3c2b1a" {
+		t.Errorf("Unexpected NAT settings %v %v", profile.NatType, profile.ExternalNetProfileID)
+	}
+	if len(profile.DefinedRanges) != 1 || profile.DefinedRanges[0].EndIPv4Address != "192.168.10.50" {
+		t.Fatalf("Unexpected ranges %v", profile.DefinedRanges)
+	}
+
+	httpmock.RegisterResponder("PUT", "http://localhost/iaas-proxy-provider/api/network/profiles/9e1f7a55-3c2b-4d8e-b6a4-1f0e2d3c4b5a",
+		func(req *http.Request) (*http.Response, error) {
+			document := make(map[string]interface{})
+			if err := json.NewDecoder(req.Body).Decode(&document); err != nil {
+				return nil, err
+			}
+			if document["@type"] != "NATNetworkProfile" {
+				return httpmock.NewStringResponse(400, `{"errors":[{"code":50505,"message":"Unknown profile type"}]}`), nil
+			}
+			return httpmock.NewStringResponse(200, ""), nil
+		})
+
+	profile.DefinedRanges[0].EndIPv4Address = "192.168.10.80"
+	if err := client.UpdateNetworkProfile(profile); err != nil {
+		t.Errorf("Failed to update network profile %v", err)
+	}
+}
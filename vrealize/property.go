@@ -0,0 +1,284 @@
+package vrealize
+
+import (
+	"fmt"
+	"strconv"
+)
+
+//Property definition data types
+var propertyDataTypes = []string{
+	"STRING",
+	"INTEGER",
+	"DECIMAL",
+	"BOOLEAN",
+	"DATE_TIME",
+	"SECURE_STRING",
+}
+
+//Property definition display controls
+var propertyDisplayAdvices = []string{
+	"TEXTBOX",
+	"TEXTAREA",
+	"DROPDOWN",
+	"SLIDER",
+	"CHECKBOX",
+	"DATE_TIME_PICKER",
+	"EMAIL",
+	"LINK",
+	"PASSWORD",
+	"SPINNER",
+	"YES_NO",
+}
+
+//Property definition facets and value list types
+const (
+	propertyMandatoryFacet = "mandatory"
+	propertyMinValueFacet  = "minValue"
+	propertyMaxValueFacet  = "maxValue"
+	propertyMinLengthFacet = "minLength"
+	propertyMaxLengthFacet = "maxLength"
+
+	staticValueListType       = "staticValueList"
+	scriptActionValueListType = "scriptAction"
+
+	constantParameterValue = "constant"
+	pathParameterValue     = "path"
+)
+
+//PropertyDefinition - This struct holds a custom property definition, its id is the property name
+type PropertyDefinition struct {
+	ID            string                 `json:"id"`
+	Label         string                 `json:"label"`
+	Description   string                 `json:"description"`
+	DataType      PropertyDataType       `json:"dataType"`
+	DisplayAdvice string                 `json:"displayAdvice"`
+	IsMultiValued bool                   `json:"isMultiValued"`
+	OrderIndex    *int                   `json:"orderIndex,omitempty"`
+	TenantID      string                 `json:"tenantId"`
+	Facets        map[string]interface{} `json:"facets"`
+	ValueList     map[string]interface{} `json:"valueList,omitempty"`
+}
+
+//PropertyDataType - This struct holds the data type of a property definition
+type PropertyDataType struct {
+	Type   string `json:"type"`
+	TypeID string `json:"typeId"`
+}
+
+//PropertyGroup - This struct holds a property group, its id is the group name
+type PropertyGroup struct {
+	ID          string        `json:"id"`
+	Label       string        `json:"label"`
+	Description string        `json:"description"`
+	TenantID    string        `json:"tenantId"`
+	Properties  ExtensionData `json:"properties"`
+}
+
+//constantFacet - To build a facet with a constant value, e.g. a mandatory flag
+func constantFacet(valueType string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "constantClause", "value": literalValue(valueType, value)}
+}
+
+//facetValue - To read the constant value of a facet, nil if the facet is not set
+func (p *PropertyDefinition) facetValue(facet string) interface{} {
+	clause, _ := p.Facets[facet].(map[string]interface{})
+	value, _ := clause["value"].(map[string]interface{})
+	return value["value"]
+}
+
+//facetLimit - To read a numeric facet as it is configured, empty if the facet is not set
+func (p *PropertyDefinition) facetLimit(facet string) string {
+	limit, ok := p.facetValue(facet).(float64)
+	if !ok {
+		return ""
+	}
+	return strconv.FormatFloat(limit, 'f', -1, 64)
+}
+
+//actionInputParameter - To build an input parameter of the vRO action of a value list, a bound
+//parameter passes the value of the custom property with the given name
+func actionInputParameter(name string, value string, bind bool) map[string]interface{} {
+	parameterValue := map[string]interface{}{"type": constantParameterValue, "value": literalValue("string", value)}
+	if bind {
+		parameterValue = map[string]interface{}{"type": pathParameterValue, "path": value}
+	}
+	return map[string]interface{}{"name": name, "value": parameterValue}
+}
+
+//flattenActionInputParameters - To read the input parameters of the vRO action of a value list
+func (p *PropertyDefinition) flattenActionInputParameters() []map[string]interface{} {
+	var parameters []map[string]interface{}
+	inputParameters, _ := p.ValueList["inputParameters"].([]interface{})
+	for _, item := range inputParameters {
+		parameter, _ := item.(map[string]interface{})
+		parameterValue, _ := parameter["value"].(map[string]interface{})
+		value := map[string]interface{}{"name": parameter["name"], "bind": false}
+		if parameterValue["type"] == pathParameterValue {
+			value["value"] = parameterValue["path"]
+			value["bind"] = true
+		} else {
+			literal, _ := parameterValue["value"].(map[string]interface{})
+			value["value"] = fmt.Sprint(literal["value"])
+		}
+		parameters = append(parameters, value)
+	}
+	return parameters
+}
+
+//GetPropertyDefinition - To read a property definition by its name
+func (c *APIClient) GetPropertyDefinition(name string) (*PropertyDefinition, error) {
+	path := fmt.Sprintf("/properties-service/api/propertydefinitions/%s", name)
+
+	definition := new(PropertyDefinition)
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Get(path).Receive(definition, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, NotFoundError{"property definition", name}
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return definition, nil
+}
+
+//CreatePropertyDefinition - To create a property definition for the tenant of the client
+func (c *APIClient) CreatePropertyDefinition(definition *PropertyDefinition) error {
+	definition.TenantID = c.Tenant
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Post("/properties-service/api/propertydefinitions").
+		BodyJSON(definition).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//UpdatePropertyDefinition - To replace a property definition
+func (c *APIClient) UpdatePropertyDefinition(definition *PropertyDefinition) error {
+	path := fmt.Sprintf("/properties-service/api/propertydefinitions/%s", definition.ID)
+	definition.TenantID = c.Tenant
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(path).BodyJSON(definition).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//DeletePropertyDefinition - To delete a property definition
+func (c *APIClient) DeletePropertyDefinition(name string) error {
+	path := fmt.Sprintf("/properties-service/api/propertydefinitions/%s", name)
+
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Delete(path).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == 404 {
+		return NotFoundError{"property definition", name}
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//GetPropertyGroup - To read a property group by its name
+func (c *APIClient) GetPropertyGroup(name string) (*PropertyGroup, error) {
+	path := fmt.Sprintf("/properties-service/api/propertygroups/%s", name)
+
+	group := new(PropertyGroup)
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Get(path).Receive(group, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, NotFoundError{"property group", name}
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return group, nil
+}
+
+//CreatePropertyGroup - To create a property group for the tenant of the client
+func (c *APIClient) CreatePropertyGroup(group *PropertyGroup) error {
+	group.TenantID = c.Tenant
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Post("/properties-service/api/propertygroups").
+		BodyJSON(group).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//UpdatePropertyGroup - To replace a property group
+func (c *APIClient) UpdatePropertyGroup(group *PropertyGroup) error {
+	path := fmt.Sprintf("/properties-service/api/propertygroups/%s", group.ID)
+	group.TenantID = c.Tenant
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(path).BodyJSON(group).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//DeletePropertyGroup - To delete a property group
+func (c *APIClient) DeletePropertyGroup(name string) error {
+	path := fmt.Sprintf("/properties-service/api/propertygroups/%s", name)
+
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Delete(path).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == 404 {
+		return NotFoundError{"property group", name}
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package vrealize
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform/helper/schema"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAPIClient_GetPropertyDefinition(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost/properties-service/api/propertydefinitions/Custom.DiskSize",
+		httpmock.NewStringResponder(200, `{"id":"Custom.DiskSize","label":"Disk size","description":"","dataType":{"type":"primitive","typeId":"INTEGER"},"displayAdvice":"DROPDOWN","isMultiValued":false,"orderIndex":null,"tenantId":"vsphere.local","facets":{"mandatory":{"type":"constantClause","value":{"type":"boolean","value":true}},"maxValue":{"type":"constantClause","value":{"type":"integer","value":500}}},"valueList":{"type":"staticValueList","values":[{"underlyingValue":{"type":"integer","value":50},"label":"Small"},{"underlyingValue":{"type":"integer","value":200},"label":"Large"}]}}`))
+
+	definition, err := client.GetPropertyDefinition("Custom.DiskSize")
+	if err != nil {
+		t.Fatalf("Failed to get property definition %v", err)
+	}
+	if mandatory, _ := definition.facetValue(propertyMandatoryFacet).(bool); !mandatory {
+		t.Errorf("Expected property definition to be mandatory")
+	}
+	if maxValue, _ := definition.facetValue(propertyMaxValueFacet).(float64); maxValue != 500 {
+		t.Errorf("Expected max value 500, got %v", maxValue)
+	}
+	if definition.facetValue(propertyMinValueFacet) != nil {
+		t.Errorf("Expected no min value, got %v", definition.facetValue(propertyMinValueFacet))
+	}
+
+	httpmock.RegisterResponder("GET", "http://localhost/properties-service/api/propertydefinitions/Custom.Missing",
+		httpmock.NewStringResponder(404, `{"errors":[{"code":10101,"source":null,"message":"Property definition not found","systemMessage":"Property definition not found","moreInfoUrl":null}]}`))
+
+	if _, err := client.GetPropertyDefinition("Custom.Missing"); !isNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+func TestPropertyLiteral(t *testing.T) {
+	literal, err := propertyLiteral("INTEGER", "200")
+	if err != nil || literal["type"] != "integer" || literal["value"] != 200 {
+		t.Errorf("Expected integer literal 200, got %v %v", literal, err)
+	}
+	if _, err := propertyLiteral("INTEGER", "large"); err == nil {
+		t.Errorf("Converted large to an integer.")
+	}
+	literal, _ = propertyLiteral("STRING", "large")
+	if literal["type"] != "string" || literal["value"] != "large" {
+		t.Errorf("Expected string literal large, got %v", literal)
+	}
+}
+
+func TestCreatePropertyDefinitionLimits(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var created PropertyDefinition
+	httpmock.RegisterResponder("POST", "http://localhost/properties-service/api/propertydefinitions",
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&created); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(201, ``), nil
+		})
+	httpmock.RegisterResponder("GET", "http://localhost/properties-service/api/propertydefinitions/Custom.Weight",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := json.Marshal(created)
+			return httpmock.NewBytesResponse(200, body), nil
+		})
+
+	d := schema.TestResourceDataRaw(t, propertyDefinitionSchema(), map[string]interface{}{
+		"name":       "Custom.Weight",
+		"data_type":  "DECIMAL",
+		"min_value":  "0",
+		"max_value":  "2.5",
+		"min_length": "0",
+	})
+	if err := createPropertyDefinition(d, &client); err != nil {
+		t.Fatalf("Failed to create property definition %v", err)
+	}
+
+	expected := map[string]interface{}{
+		propertyMinValueFacet:  map[string]interface{}{"type": "constantClause", "value": map[string]interface{}{"type": "decimal", "value": float64(0)}},
+		propertyMaxValueFacet:  map[string]interface{}{"type": "constantClause", "value": map[string]interface{}{"type": "decimal", "value": 2.5}},
+		propertyMinLengthFacet: map[string]interface{}{"type": "constantClause", "value": map[string]interface{}{"type": "integer", "value": float64(0)}},
+	}
+	if !reflect.DeepEqual(created.Facets, expected) {
+		t.Errorf("Expected facets %v, got %v", expected, created.Facets)
+	}
+
+	//Limits which are not set are read back as empty, a limit of 0 as 0
+	for field, value := range map[string]string{"min_value": "0", "max_value": "2.5", "min_length": "0", "max_length": ""} {
+		if d.Get(field).(string) != value {
+			t.Errorf("Expected %s %q, got %q", field, value, d.Get(field))
+		}
+	}
+}
+
+func TestCreatePropertyDefinitionActionValueList(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var created map[string]interface{}
+	httpmock.RegisterResponder("POST", "http://localhost/properties-service/api/propertydefinitions",
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&created); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(201, ``), nil
+		})
+	httpmock.RegisterResponder("GET", "http://localhost/properties-service/api/propertydefinitions/Custom.Network",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := json.Marshal(created)
+			return httpmock.NewBytesResponse(200, body), nil
+		})
+
+	d := schema.TestResourceDataRaw(t, propertyDefinitionSchema(), map[string]interface{}{
+		"name":              "Custom.Network",
+		"data_type":         "STRING",
+		"display_control":   "DROPDOWN",
+		"value_list_action": "com.mycompany.network/getNetworks",
+		"value_list_action_parameter": []interface{}{
+			map[string]interface{}{"name": "vcenter", "value": "vc01.corp.local"},
+			map[string]interface{}{"name": "datacenter", "value": "Custom.Datacenter", "bind": true},
+		},
+	})
+	if err := createPropertyDefinition(d, &client); err != nil {
+		t.Fatalf("Failed to create property definition %v", err)
+	}
+
+	var expected interface{}
+	json.Unmarshal([]byte(`{"type":"scriptAction","id":"com.mycompany.network/getNetworks","inputParameters":[
+		{"name":"vcenter","value":{"type":"constant","value":{"type":"string","value":"vc01.corp.local"}}},
+		{"name":"datacenter","value":{"type":"path","path":"Custom.Datacenter"}}]}`), &expected)
+	if !reflect.DeepEqual(created["valueList"], expected) {
+		t.Errorf("Expected value list %v, got %v", expected, created["valueList"])
+	}
+
+	if d.Get("value_list_action").(string) != "com.mycompany.network/getNetworks" ||
+		d.Get("value_list_action_parameter.0.value").(string) != "vc01.corp.local" ||
+		!d.Get("value_list_action_parameter.1.bind").(bool) {
+		t.Errorf("Unexpected value list action %v with parameters %v",
+			d.Get("value_list_action"), d.Get("value_list_action_parameter"))
+	}
+}
@@ -10,9 +10,10 @@ import (
 //also the config function and resource mapping
 func Provider() terraform.ResourceProvider {
 	return &schema.Provider{
-		Schema:        providerSchema(),
-		ConfigureFunc: providerConfig,
-		ResourcesMap:  providerResources(),
+		Schema:         providerSchema(),
+		ConfigureFunc:  providerConfig,
+		ResourcesMap:   providerResources(),
+		DataSourcesMap: providerDataSources(),
 	}
 }
 
@@ -75,6 +76,34 @@ func providerConfig(r *schema.ResourceData) (interface{}, error) {
 //Function use - set machine resource details based on machine type
 func providerResources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"vra7_resource": ResourceMachine(),
+		"vra7_resource":                   ResourceMachine(),
+		"vra7_resource_action":            ResourceAction(),
+		"vra7_machine_snapshot":           ResourceMachineSnapshot(),
+		"vra7_business_group":             ResourceBusinessGroup(),
+		"vra7_entitlement":                ResourceEntitlement(),
+		"vra7_reservation":                ResourceReservation(),
+		"vra7_reservation_policy":         ResourceReservationPolicy(),
+		"vra7_storage_reservation_policy": ResourceStorageReservationPolicy(),
+		"vra7_blueprint":                  ResourceBlueprint(),
+		"vra7_catalog_item":               ResourceCatalogItem(),
+		"vra7_catalog_service":            ResourceCatalogService(),
+		"vra7_property_definition":        ResourcePropertyDefinition(),
+		"vra7_property_group":             ResourcePropertyGroup(),
+		"vra7_network_profile":            ResourceNetworkProfile(),
+		"vra7_approval_policy":            ResourceApprovalPolicy(),
+		"vra7_approval":                   ResourceApproval(),
+		"vra7_event_subscription":         ResourceEventSubscription(),
+	}
+}
+
+//Function use - set data source details
+func providerDataSources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"vra7_catalog_item":               DataSourceCatalogItem(),
+		"vra7_catalog_item_template":      DataSourceCatalogItemTemplate(),
+		"vra7_catalog_items":              DataSourceCatalogItems(),
+		"vra7_business_group":             DataSourceBusinessGroup(),
+		"vra7_reservation_policy":         DataSourceReservationPolicy(),
+		"vra7_storage_reservation_policy": DataSourceStorageReservationPolicy(),
 	}
 }
@@ -0,0 +1,234 @@
+package vrealize
+
+import (
+	"fmt"
+	"path"
+)
+
+//vSphere reservation type and the extension data keys of its allocations
+const (
+	vSphereReservationType = "Infrastructure.Reservation.Virtual.vSphere"
+
+	reservationComputeResourceKey = "computeResource"
+	reservationMachineQuotaKey    = "machineQuota"
+	reservationMemoryKey          = "reservationMemory"
+	reservationStoragesKey        = "reservationStorages"
+	reservationNetworksKey        = "reservationNetworks"
+
+	reservationMemorySizeKey      = "memoryReservedSizeMb"
+	reservationStoragePathKey     = "storagePath"
+	reservationStorageSizeKey     = "storageReservedSizeGB"
+	reservationStoragePriorityKey = "storageReservationPriority"
+	reservationStorageEnabledKey  = "storageEnabled"
+	reservationNetworkPathKey     = "networkPath"
+	reservationNetworkProfileKey  = "networkProfile"
+)
+
+//Reservation - This struct holds a reservation of the reservation service
+type Reservation struct {
+	ID                  string        `json:"id,omitempty"`
+	Name                string        `json:"name"`
+	ReservationTypeID   string        `json:"reservationTypeId"`
+	TenantID            string        `json:"tenantId"`
+	SubTenantID         string        `json:"subTenantId"`
+	Enabled             bool          `json:"enabled"`
+	Priority            int           `json:"priority"`
+	ReservationPolicyID string        `json:"reservationPolicyId,omitempty"`
+	AlertPolicy         interface{}   `json:"alertPolicy,omitempty"`
+	ExtensionData       ExtensionData `json:"extensionData"`
+}
+
+//reservationFieldValues - This struct holds the allowed values of a reservation field
+type reservationFieldValues struct {
+	Values []struct {
+		UnderlyingValue map[string]interface{} `json:"underlyingValue"`
+		Label           string                 `json:"label"`
+	} `json:"values"`
+}
+
+//entityRefValue - To build a reference to an IaaS entity, e.g. a compute resource or a datastore
+func entityRefValue(classID string, id string, label string) map[string]interface{} {
+	return map[string]interface{}{"type": "entityRef", "classId": classID, "id": id, "label": label}
+}
+
+//complexValue - To build a complex value holding its own extension data entries
+func complexValue(classID string, entries []ExtensionEntry) map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "complex",
+		"classId": classID,
+		"values":  ExtensionData{Entries: entries},
+	}
+}
+
+//multipleValue - To build a value holding a list of complex values
+func multipleValue(items []interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "multiple", "elementTypeId": "COMPLEX", "items": items}
+}
+
+//complexEntries - To read the extension data entries of a complex value
+func complexEntries(value map[string]interface{}) ExtensionData {
+	values, _ := value["values"].(map[string]interface{})
+	items, _ := values["entries"].([]interface{})
+
+	var entries ExtensionData
+	for _, item := range items {
+		entry, _ := item.(map[string]interface{})
+		key, _ := entry["key"].(string)
+		entries.Entries = append(entries.Entries, ExtensionEntry{Key: key, Value: entry["value"]})
+	}
+	return entries
+}
+
+//multipleItems - To read the complex values of a multiple value
+func multipleItems(value map[string]interface{}) []map[string]interface{} {
+	items, _ := value["items"].([]interface{})
+
+	var complexItems []map[string]interface{}
+	for _, item := range items {
+		if complexItem, ok := item.(map[string]interface{}); ok {
+			complexItems = append(complexItems, complexItem)
+		}
+	}
+	return complexItems
+}
+
+//literalInt - To read an integer literal, decoded JSON numbers are float64
+func literalInt(value map[string]interface{}) int {
+	switch number := value["value"].(type) {
+	case float64:
+		return int(number)
+	case int:
+		return number
+	}
+	return 0
+}
+
+//literalBool - To read a boolean literal
+func literalBool(value map[string]interface{}) bool {
+	flag, _ := value["value"].(bool)
+	return flag
+}
+
+//entityRefLabel - To read the label of an entity reference
+func entityRefLabel(value map[string]interface{}) string {
+	label, _ := value["label"].(string)
+	return label
+}
+
+//GetReservation - To read a reservation by its id
+func (c *APIClient) GetReservation(reservationID string) (*Reservation, error) {
+	path := fmt.Sprintf("/reservation-service/api/reservations/%s", reservationID)
+
+	reservation := new(Reservation)
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Get(path).Receive(reservation, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, NotFoundError{"reservation", reservationID}
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return reservation, nil
+}
+
+//CreateReservation - To create a reservation and return its id
+func (c *APIClient) CreateReservation(reservation *Reservation) (string, error) {
+	reservation.TenantID = c.Tenant
+
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Post("/reservation-service/api/reservations").
+		BodyJSON(reservation).Receive(nil, apiError)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !apiError.isEmpty() {
+		return "", apiError
+	}
+
+	if resp.StatusCode != 201 {
+		return "", fmt.Errorf("reservation creation failed with status %s", resp.Status)
+	}
+
+	//The created reservation is referred by the location header
+	return path.Base(resp.Header.Get("Location")), nil
+}
+
+//UpdateReservation - To replace a reservation
+func (c *APIClient) UpdateReservation(reservation *Reservation) error {
+	path := fmt.Sprintf("/reservation-service/api/reservations/%s", reservation.ID)
+	reservation.TenantID = c.Tenant
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(path).BodyJSON(reservation).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//DeleteReservation - To delete a reservation
+func (c *APIClient) DeleteReservation(reservationID string) error {
+	path := fmt.Sprintf("/reservation-service/api/reservations/%s", reservationID)
+
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Delete(path).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == 404 {
+		return NotFoundError{"reservation", reservationID}
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//GetReservationFieldValue - To look up an allowed value of a reservation field, e.g. a compute
+//resource or a datastore, by its label. Fields depending on other fields are given their values.
+func (c *APIClient) GetReservationFieldValue(fieldID string, label string, dependencies []ExtensionEntry) (map[string]interface{}, error) {
+	path := fmt.Sprintf("/reservation-service/api/data-service/schema/%s/default/%s/values",
+		vSphereReservationType, fieldID)
+	if dependencies == nil {
+		dependencies = []ExtensionEntry{}
+	}
+	body := map[string]interface{}{
+		"text":             "",
+		"dependencyValues": ExtensionData{Entries: dependencies},
+	}
+
+	fieldValues := new(reservationFieldValues)
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Post(path).BodyJSON(body).Receive(fieldValues, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+
+	for _, value := range fieldValues.Values {
+		if value.Label == label {
+			return value.UnderlyingValue, nil
+		}
+	}
+	return nil, fmt.Errorf("%s %s was not found", fieldID, label)
+}
@@ -0,0 +1,149 @@
+package vrealize
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+//Reservation policy types
+const (
+	computeReservationPolicyType = "Infrastructure.Reservation.Policy.ComputeResource"
+	storageReservationPolicyType = "Infrastructure.Reservation.Policy.Storage"
+)
+
+//ReservationPolicy - This struct holds a reservation or storage reservation policy
+type ReservationPolicy struct {
+	ID                      string `json:"id,omitempty"`
+	Name                    string `json:"name"`
+	Description             string `json:"description"`
+	ReservationPolicyTypeID string `json:"reservationPolicyTypeId"`
+	TenantID                string `json:"tenantId"`
+}
+
+//reservationPolicyList - This struct holds one page of reservation policies
+type reservationPolicyList struct {
+	Content  []ReservationPolicy `json:"content"`
+	Metadata Metadata            `json:"metadata"`
+}
+
+//GetReservationPolicy - To read a reservation policy by its id
+func (c *APIClient) GetReservationPolicy(policyID string) (*ReservationPolicy, error) {
+	path := fmt.Sprintf("/reservation-service/api/reservations/policies/%s", policyID)
+
+	policy := new(ReservationPolicy)
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Get(path).Receive(policy, apiError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, NotFoundError{"reservation policy", policyID}
+	}
+
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return policy, nil
+}
+
+//GetReservationPolicyByName - To read a reservation policy of the given type by its exact name
+func (c *APIClient) GetReservationPolicyByName(policyTypeID string, name string) (*ReservationPolicy, error) {
+	filter := fmt.Sprintf("name eq '%s'", strings.Replace(name, "'", "''", -1))
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("limit", "100")
+		query.Set("$filter", filter)
+		path := fmt.Sprintf("/reservation-service/api/reservations/policies?%s", query.Encode())
+
+		template := new(reservationPolicyList)
+		apiError := new(APIError)
+		_, err := c.HTTPClient.New().Get(path).Receive(template, apiError)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !apiError.isEmpty() {
+			return nil, apiError
+		}
+
+		//Compute and storage policies share the list, so the type has to match as well
+		for _, policy := range template.Content {
+			if policy.Name == name && policy.ReservationPolicyTypeID == policyTypeID {
+				return &policy, nil
+			}
+		}
+		if page >= template.Metadata.TotalPages {
+			return nil, fmt.Errorf("No reservation policy of type %s found with name %s", policyTypeID, name)
+		}
+	}
+}
+
+//CreateReservationPolicy - To create a reservation policy and return its id
+func (c *APIClient) CreateReservationPolicy(policy *ReservationPolicy) (string, error) {
+	policy.TenantID = c.Tenant
+
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Post("/reservation-service/api/reservations/policies").
+		BodyJSON(policy).Receive(nil, apiError)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !apiError.isEmpty() {
+		return "", apiError
+	}
+
+	if resp.StatusCode != 201 {
+		return "", fmt.Errorf("reservation policy creation failed with status %s", resp.Status)
+	}
+
+	//The created policy is referred by the location header
+	return path.Base(resp.Header.Get("Location")), nil
+}
+
+//UpdateReservationPolicy - To update name and description of a reservation policy
+func (c *APIClient) UpdateReservationPolicy(policy *ReservationPolicy) error {
+	path := fmt.Sprintf("/reservation-service/api/reservations/policies/%s", policy.ID)
+	policy.TenantID = c.Tenant
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Put(path).BodyJSON(policy).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
+
+//DeleteReservationPolicy - To delete a reservation policy
+func (c *APIClient) DeleteReservationPolicy(policyID string) error {
+	path := fmt.Sprintf("/reservation-service/api/reservations/policies/%s", policyID)
+
+	apiError := new(APIError)
+	resp, err := c.HTTPClient.New().Delete(path).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == 404 {
+		return NotFoundError{"reservation policy", policyID}
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package vrealize
+
+import (
+	"gopkg.in/jarcoal/httpmock.v1"
+	"net/http"
+	"testing"
+)
+
+func TestAPIClient_GetReservation(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost/reservation-service/api/reservations/b2c4d0f7-2a6e-4d1c-8f3b-6c0e9a7d5e14",
+		httpmock.NewStringResponder(200, `{"id":"b2c4d0f7-2a6e-4d1c-8f3b-6c0e9a7d5e14","name":"Content-Cluster01","reservationTypeId":"Infrastructure.Reservation.Virtual.vSphere","tenantId":"vsphere.local","subTenantId":"53619006-56bb-4788-9723-9eab79752cc1","enabled":true,"priority":1,"reservationPolicyId":null,"alertPolicy":{"enabled":false,"frequencyReminder":0,"emailBgMgr":false,"recipients":[],"alerts":[]},"extensionData":{"entries":[{"key":"computeResource","value":{"type":"entityRef","classId":"ComputeResource","id":"1d4f2e7a-6b5c-4a3d-9e8f-7a6b5c4d3e2f","componentId":null,"label":"Cluster01"}},{"key":"machineQuota","value":{"type":"integer","value":10}},{"key":"reservationMemory","value":{"type":"complex","componentTypeId":"com.vmware.csp.iaas.blueprint.service","componentId":null,"classId":"Infrastructure.Reservation.Memory","typeFilter":null,"values":{"entries":[{"key":"memoryReservedSizeMb","value":{"type":"integer","value":8192}}]}}},{"key":"reservationStorages","value":{"type":"multiple","elementTypeId":"COMPLEX","items":[{"type":"complex","componentTypeId":"com.vmware.csp.iaas.blueprint.service","componentId":null,"classId":"Infrastructure.Reservation.Storage","typeFilter":null,"values":{"entries":[{"key":"storagePath","value":{"type":"entityRef","classId":"Storage","id":"8c7b6a5d-4e3f-4a2b-9c1d-0e9f8a7b6c5d","componentId":null,"label":"datastore1"}},{"key":"storageReservedSizeGB","value":{"type":"integer","value":200}},{"key":"storageReservationPriority","value":{"type":"integer","value":1}},{"key":"storageEnabled","value":{"type":"boolean","value":true}}]}}]}}]}}`))
+
+	reservation, err := client.GetReservation("b2c4d0f7-2a6e-4d1c-8f3b-6c0e9a7d5e14")
+	if err != nil {
+		t.Fatalf("Failed to get reservation %v", err)
+	}
+
+	extensionData := reservation.ExtensionData
+	if label := entityRefLabel(extensionData.extensionEntry(reservationComputeResourceKey)); label != "Cluster01" {
+		t.Errorf("Expected compute resource Cluster01, got %v", label)
+	}
+	if quota := literalInt(extensionData.extensionEntry(reservationMachineQuotaKey)); quota != 10 {
+		t.Errorf("Expected machine quota 10, got %v", quota)
+	}
+	memory := complexEntries(extensionData.extensionEntry(reservationMemoryKey))
+	if size := literalInt(memory.extensionEntry(reservationMemorySizeKey)); size != 8192 {
+		t.Errorf("Expected 8192 MB of memory, got %v", size)
+	}
+
+	storages := multipleItems(extensionData.extensionEntry(reservationStoragesKey))
+	if len(storages) != 1 {
+		t.Fatalf("Expected one storage, got %v", len(storages))
+	}
+	storage := complexEntries(storages[0])
+	if label := entityRefLabel(storage.extensionEntry(reservationStoragePathKey)); label != "datastore1" {
+		t.Errorf("Expected storage datastore1, got %v", label)
+	}
+	if size := literalInt(storage.extensionEntry(reservationStorageSizeKey)); size != 200 {
+		t.Errorf("Expected 200 GB of storage, got %v", size)
+	}
+	if !literalBool(storage.extensionEntry(reservationStorageEnabledKey)) {
+		t.Errorf("Expected storage to be enabled")
+	}
+}
+
+func TestAPIClient_GetReservationFieldValue(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://localhost/reservation-service/api/data-service/schema/"+
+		"Infrastructure.Reservation.Virtual.vSphere/default/computeResource/values",
+		httpmock.NewStringResponder(200, `{"values":[{"underlyingValue":{"type":"entityRef","classId":"ComputeResource","id":"1d4f2e7a-6b5c-4a3d-9e8f-7a6b5c4d3e2f","componentId":null,"label":"Cluster01"},"label":"Cluster01"},{"underlyingValue":{"type":"entityRef","classId":"ComputeResource","id":"2e5a3f8b-7c6d-4b4e-8f9a-8b7c6d5e4f3a","componentId":null,"label":"Cluster02"},"label":"Cluster02"}]}`))
+
+	computeResource, err := client.GetReservationFieldValue(reservationComputeResourceKey, "Cluster02", nil)
+	if err != nil {
+		t.Fatalf("Failed to get compute resource %v", err)
+	}
+	if computeResource["id"] != "2e5a3f8b-7c6d-4b4e-8f9a-8b7c6d5e4f3a" {
+		t.Errorf("Expected compute resource 2e5a3f8b-7c6d-4b4e-8f9a-8b7c6d5e4f3a, got %v", computeResource["id"])
+	}
+
+	if _, err := client.GetReservationFieldValue(reservationComputeResourceKey, "Cluster03", nil); err == nil {
+		t.Errorf("Found a compute resource which does not exist.")
+	}
+}
+
+func TestAPIClient_GetReservationPolicyByName(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	//Every lookup reads the list again, so each call gets a fresh response body
+	httpmock.RegisterResponder("GET", "http://localhost/reservation-service/api/reservations/policies",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(200, `{"links":[],"content":[{"id":"4f1c2b3a-5d6e-4f7a-8b9c-0d1e2f3a4b5c","name":"Gold","description":"Gold datastores","reservationPolicyTypeId":"Infrastructure.Reservation.Policy.Storage","tenantId":"vsphere.local"},{"id":"6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d","name":"Gold","description":"Gold clusters","reservationPolicyTypeId":"Infrastructure.Reservation.Policy.ComputeResource","tenantId":"vsphere.local"}],"metadata":{"size":100,"totalElements":2,"totalPages":1,"number":1,"offset":0}}`), nil
+		})
+
+	policy, err := client.GetReservationPolicyByName(computeReservationPolicyType, "Gold")
+	if err != nil {
+		t.Fatalf("Failed to get reservation policy %v", err)
+	}
+	if policy.ID != "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d" {
+		t.Errorf("Expected reservation policy 6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d, got %v", policy.ID)
+	}
+
+	policy, err = client.GetReservationPolicyByName(storageReservationPolicyType, "Gold")
+	if err != nil || policy.ID != "4f1c2b3a-5d6e-4f7a-8b9c-0d1e2f3a4b5c" {
+		t.Errorf("Expected storage reservation policy 4f1c2b3a-5d6e-4f7a-8b9c-0d1e2f3a4b5c, got %v %v", policy, err)
+	}
+
+	if _, err := client.GetReservationPolicyByName(computeReservationPolicyType, "Silver"); err == nil {
+		t.Errorf("Found a reservation policy which does not exist.")
+	}
+}
@@ -4,26 +4,72 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
 	"encoding/json"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 //ResourceViewsTemplate - is used to store information
 //related to resource template information.
 type ResourceViewsTemplate struct {
-	Content []struct {
-		ResourceID   string `json:"resourceId"`
-		RequestState string `json:"requestState"`
-		Links        []struct {
-			Href string `json:"href"`
-			Rel  string `json:"rel"`
-		} `json:"links"`
-	} `json:"content"`
-	Links []interface{} `json:"links"`
+	Content []ResourceView `json:"content"`
+	Links   []interface{}  `json:"links"`
+}
+
+//ResourceView - is used to store a single resource of a request,
+//i.e. the deployment or one of its components
+type ResourceView struct {
+	ResourceID   string                 `json:"resourceId"`
+	RequestState string                 `json:"requestState"`
+	ResourceType string                 `json:"resourceType"`
+	Status       string                 `json:"status"`
+	Data         map[string]interface{} `json:"data"`
+	Lease        struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	} `json:"lease"`
+	Links []struct {
+		Href string `json:"href"`
+		Rel  string `json:"rel"`
+	} `json:"links"`
+}
+
+//isMachine - machine resources carry the IaaS machine id in their data
+func (r ResourceView) isMachine() bool {
+	_, ok := r.Data["machineId"]
+	return ok
+}
+
+//componentName - name of the blueprint component the resource was provisioned for
+func (r ResourceView) componentName() string {
+	component, _ := r.Data["Component"].(string)
+	return component
+}
+
+//deploymentResourceType - resource type of the deployment which owns all components of a request
+const deploymentResourceType = "composition.resource.type.deployment"
+
+//requestPollInterval - interval between two request status checks
+var requestPollInterval = 30 * time.Second
+
+//Ways to handle a request waiting for approval, either until wait_timeout expires,
+//without a time limit or by failing right away
+const (
+	pendingApprovalTimeout = "timeout"
+	pendingApprovalWait    = "wait"
+	pendingApprovalFail    = "fail"
+)
+
+//pendingApprovalActions - supported values of on_pending_approval
+var pendingApprovalActions = []string{
+	pendingApprovalTimeout,
+	pendingApprovalWait,
+	pendingApprovalFail,
 }
 
 //RequestStatusView - used to store REST response of
@@ -33,7 +79,9 @@ type RequestStatusView struct {
 		RequestCompletionState string `json:"requestCompletionState"`
 		CompletionDetails      string `json:"CompletionDetails"`
 	} `json:"requestCompletion"`
-	Phase string `json:"phase"`
+	Phase          string `json:"phase"`
+	ApprovalStatus string `json:"approvalStatus"`
+	RequestedFor   string `json:"requestedFor"`
 }
 
 //RequestMachineResponse - used to store response of request
@@ -108,21 +156,84 @@ func setResourceSchema() map[string]*schema.Schema {
 			Type:     schema.TypeString,
 			Optional: true,
 		},
+		"catalog_name_match": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      catalogNameMatchExact,
+			ValidateFunc: validation.StringInSlice(catalogNameMatchTypes, false),
+		},
 		"catalog_id": {
 			Type:     schema.TypeString,
 			Computed: true,
 			Optional: true,
 		},
 		"businessgroup_id": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:          schema.TypeString,
+			Computed:      true,
+			Optional:      true,
+			ConflictsWith: []string{"businessgroup_name"},
+		},
+		"businessgroup_name": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"businessgroup_id"},
+		},
+		"requested_for": {
+			Type:             schema.TypeString,
+			Computed:         true,
+			Optional:         true,
+			ForceNew:         true,
+			DiffSuppressFunc: suppressCaseInsensitive,
+		},
+		"lease_days": {
+			Type:     schema.TypeInt,
 			Optional: true,
+			ForceNew: true,
+		},
+		"lease_end": {
+			Type:             schema.TypeString,
+			Computed:         true,
+			Optional:         true,
+			ValidateFunc:     validateLeaseEnd,
+			DiffSuppressFunc: suppressEqualLeaseEnd,
+		},
+		"power_state": {
+			Type:         schema.TypeString,
+			Computed:     true,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(powerStates, false),
+		},
+		"component_power_state": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     schema.TypeString,
+		},
+		"power_off_before_destroy": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
 		},
 		"wait_timeout": {
 			Type:     schema.TypeInt,
 			Optional: true,
 			Default:  15,
 		},
+		"on_pending_approval": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      pendingApprovalTimeout,
+			ValidateFunc: validation.StringInSlice(pendingApprovalActions, false),
+		},
+		"approval_status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"approvers": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
 		"request_status": {
 			Type:     schema.TypeString,
 			Computed: true,
@@ -164,6 +275,31 @@ func setResourceSchema() map[string]*schema.Schema {
 	}
 }
 
+//validateLeaseEnd - lease_end has to be a RFC 3339 timestamp
+func validateLeaseEnd(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.Parse(time.RFC3339, v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a RFC 3339 timestamp, e.g. 2018-01-31T18:00:00Z: %v", k, err))
+	}
+	return
+}
+
+//suppressCaseInsensitive - vRA may report user principals with a different case
+//than configured, e.g. requested_for, so only report a diff when they differ otherwise
+func suppressCaseInsensitive(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+//suppressEqualLeaseEnd - vRA reports lease end with its own precision and timezone,
+//so only report a diff when the timestamps differ
+func suppressEqualLeaseEnd(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, oldErr := time.Parse(time.RFC3339, old)
+	newTime, newErr := time.Parse(time.RFC3339, new)
+	if oldErr != nil || newErr != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
+
 //Function use - to create machine
 //Terraform call - terraform apply
 func changeTemplateValue(templateInterface map[string]interface{}, field string, value interface{}) (map[string]interface{}, bool) {
@@ -201,6 +337,81 @@ func addTemplateValue(templateInterface map[string]interface{}, field string, va
 	return templateInterface
 }
 
+//protectedDeploymentFields - deployment level fields which are managed through their
+//own resource arguments and therefore cannot be overridden by deployment_configuration
+var protectedDeploymentFields = []string{
+	"businessGroupId",
+	"catalogItemId",
+	"requestedFor",
+	"type",
+}
+
+//updateDeploymentConfiguration - merge deployment level user configuration into the catalog template.
+//description and reasons are set on the request itself, every other key must be an existing
+//deployment level data field of the template which is neither protected nor a component.
+func updateDeploymentConfiguration(template *CatalogItemTemplate, deploymentConfiguration map[string]interface{}) error {
+	for field, value := range deploymentConfiguration {
+		switch field {
+		case "description":
+			template.Description = value.(string)
+			continue
+		case "reasons":
+			template.Reasons = value.(string)
+			continue
+		}
+
+		for _, protectedField := range protectedDeploymentFields {
+			if field == protectedField {
+				return fmt.Errorf("deployment_configuration field %s is protected and cannot be set", field)
+			}
+		}
+
+		templateValue, ok := template.Data[field]
+		if !ok {
+			return fmt.Errorf("deployment_configuration field %s is not present in the catalog item template", field)
+		}
+		if reflect.ValueOf(templateValue).Kind() == reflect.Map {
+			return fmt.Errorf("deployment_configuration field %s is a component of the catalog item, "+
+				"use resource_configuration instead", field)
+		}
+
+		convertedValue, err := convertDeploymentValue(field, templateValue, value)
+		if err != nil {
+			return err
+		}
+		log.Printf("updateDeploymentConfiguration->%s = %v\n", field, convertedValue)
+		template.Data[field] = convertedValue
+	}
+	return nil
+}
+
+//convertDeploymentValue - deployment_configuration is a map of strings, so convert the
+//value to the type of the template value it replaces, e.g. _number_of_instances is a number
+func convertDeploymentValue(field string, templateValue interface{}, value interface{}) (interface{}, error) {
+	stringValue, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	switch templateValue.(type) {
+	case bool:
+		flag, err := strconv.ParseBool(stringValue)
+		if err != nil {
+			return nil, fmt.Errorf("deployment_configuration field %s must be a boolean, got %q", field, stringValue)
+		}
+		return flag, nil
+	case int, float64:
+		if number, err := strconv.Atoi(stringValue); err == nil {
+			return number, nil
+		}
+		number, err := strconv.ParseFloat(stringValue, 64)
+		if err != nil {
+			return nil, fmt.Errorf("deployment_configuration field %s must be a number, got %q", field, stringValue)
+		}
+		return number, nil
+	}
+	return stringValue, nil
+}
+
 //Function use - to set a create resource call
 //Terraform call - terraform apply
 func createResource(d *schema.ResourceData, meta interface{}) error {
@@ -216,17 +427,13 @@ func createResource(d *schema.ResourceData, meta interface{}) error {
 	//If catalog name is provided then get catalog ID using name for further process
 	//else if catalog id is provided then fetch catalog name
 	if len(d.Get("catalog_name").(string)) > 0 {
-		catalogID, returnErr := client.readCatalogIDByName(d.Get("catalog_name").(string))
+		catalogID, returnErr := client.readCatalogIDByName(d.Get("catalog_name").(string),
+			d.Get("catalog_name_match").(string))
 		log.Printf("createResource->catalog_id %v\n", catalogID)
 		if returnErr != nil {
 			return fmt.Errorf("%v", returnErr)
 		}
-		if catalogID == nil {
-			return fmt.Errorf("No catalog found with name %v", d.Get("catalog_name").(string))
-		} else if catalogID == "" {
-			return fmt.Errorf("No catalog found with name %v", d.Get("catalog_name").(string))
-		}
-		d.Set("catalog_id", catalogID.(string))
+		d.Set("catalog_id", catalogID)
 	} else if len(d.Get("catalog_id").(string)) > 0 {
 		CatalogName, nameError := client.readCatalogNameByID(d.Get("catalog_id").(string))
 		if nameError != nil {
@@ -240,6 +447,11 @@ func createResource(d *schema.ResourceData, meta interface{}) error {
 	templateCatalogItem, err := client.GetCatalogItem(d.Get("catalog_id").(string))
 	log.Printf("createResource->templateCatalogItem %v\n", templateCatalogItem)
 
+	//Through an exception if there is any error while getting catalog template
+	if err != nil {
+		return fmt.Errorf("Invalid CatalogItem ID %v", err)
+	}
+
 	catalogConfiguration, _ := d.Get("catalog_configuration").(map[string]interface{})
 	for field1 := range catalogConfiguration {
 		templateCatalogItem.Data[field1] = catalogConfiguration[field1]
@@ -247,10 +459,32 @@ func createResource(d *schema.ResourceData, meta interface{}) error {
 	}
 	log.Printf("createResource->templateCatalogItem.Data %v\n", templateCatalogItem.Data)
 
+	//Resolve the business group name to its id
+	if businessGroupName, ok := d.GetOk("businessgroup_name"); ok {
+		businessGroup, err := client.GetBusinessGroupByName(businessGroupName.(string))
+		if err != nil {
+			return fmt.Errorf("Invalid businessgroup_name: %v", err)
+		}
+		d.Set("businessgroup_id", businessGroup.ID)
+	}
+
 	if len(d.Get("businessgroup_id").(string)) > 0 {
 		templateCatalogItem.BusinessGroupID = d.Get("businessgroup_id").(string)
 	}
 
+	//Set the lease of the deployment in days
+	if leaseDays, ok := d.GetOk("lease_days"); ok {
+		templateCatalogItem.Data["_leaseDays"] = leaseDays.(int)
+	}
+
+	//Request the deployment on behalf of another member of the business group
+	if requestedFor, ok := d.GetOk("requested_for"); ok {
+		if err := client.validateRequestedFor(templateCatalogItem.BusinessGroupID, requestedFor.(string)); err != nil {
+			return fmt.Errorf("Invalid requested_for: %v", err)
+		}
+		templateCatalogItem.RequestedFor = requestedFor.(string)
+	}
+
 	//Get all resource keys from blueprint in array
 	var keyList []string
 	for field := range templateCatalogItem.Data {
@@ -321,27 +555,13 @@ func createResource(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 	//update template with deployment level config
-	// limit to description and reasons as other things could get us into trouble
 	deploymentConfiguration, _ := d.Get("deployment_configuration").(map[string]interface{})
-	for depField := range deploymentConfiguration {
-		fieldstr := fmt.Sprintf("%s", depField)
-		switch fieldstr {
-		case "description":
-			templateCatalogItem.Description = deploymentConfiguration[depField].(string)
-		case "reasons":
-			templateCatalogItem.Reasons = deploymentConfiguration[depField].(string)
-		default:
-			log.Printf("unknown option [%s] with value [%s] ignoring\n", depField, deploymentConfiguration[depField])
-		}
+	if err := updateDeploymentConfiguration(templateCatalogItem, deploymentConfiguration); err != nil {
+		return err
 	}
 	//Log print of template after values updated
 	log.Printf("Updated template - %v\n", templateCatalogItem.Data)
 
-	//Through an exception if there is any error while getting catalog template
-	if err != nil {
-		return fmt.Errorf("Invalid CatalogItem ID %v", err)
-	}
-
 	//Set a  create machine function call
 	requestMachine, err := client.RequestMachine(templateCatalogItem)
 
@@ -356,14 +576,30 @@ func createResource(d *schema.ResourceData, meta interface{}) error {
 	//Set request status
 	d.Set("request_status", "SUBMITTED")
 
-	waitTimeout := d.Get("wait_timeout").(int) * 60
+	//Keep the configured day-2 settings, the reads below overwrite them with the actual values
+	leaseEnd, hasLeaseEnd := d.GetOk("lease_end")
+	powerState := d.Get("power_state").(string)
+	componentPowerState := d.Get("component_power_state").(map[string]interface{})
 
-	for i := 0; i < waitTimeout/30; i++ {
-		time.Sleep(3e+10)
+	waitTimeout := time.Duration(d.Get("wait_timeout").(int)) * time.Minute
+	onPendingApproval := d.Get("on_pending_approval").(string)
+
+	for deadline := time.Now().Add(waitTimeout); time.Now().Before(deadline); {
+		time.Sleep(requestPollInterval)
 		readResource(d, meta)
 
 		if d.Get("request_status") == "SUCCESSFUL" {
-			return nil
+			//Change the lease of the new deployment if an end date is configured
+			if hasLeaseEnd {
+				if err := changeLease(d, client, leaseEnd.(string)); err != nil {
+					return err
+				}
+			}
+			//Bring the machines into the configured power state
+			if err := changePowerState(d, client, powerState, componentPowerState); err != nil {
+				return err
+			}
+			return readResource(d, meta)
 		}
 		if d.Get("request_status") == "FAILED" {
 			//If request is failed during the time then
@@ -372,6 +608,24 @@ func createResource(d *schema.ResourceData, meta interface{}) error {
 			return fmt.Errorf("instance got failed while creating." +
 				" kindly check detail for more information")
 		}
+		if d.Get("request_status") == "REJECTED" {
+			//A rejected request will never be provisioned
+			d.SetId("")
+			return fmt.Errorf("request %s was rejected", requestMachine.ID)
+		}
+		if isPendingApproval(d.Get("request_status").(string)) {
+			switch onPendingApproval {
+			case pendingApprovalFail:
+				return pendingApprovalError(d)
+			case pendingApprovalWait:
+				//The wait timeout only starts once the request got approved
+				deadline = time.Now().Add(waitTimeout)
+			}
+		}
+	}
+	if isPendingApproval(d.Get("request_status").(string)) {
+		//Keep the request in the state file, it is provisioned once approved
+		return pendingApprovalError(d)
 	}
 	if d.Get("request_status") == "IN_PROGRESS" {
 		//If request is in_progress state during the time then
@@ -391,6 +645,104 @@ func createResource(d *schema.ResourceData, meta interface{}) error {
 //Terraform call - terraform refresh
 func updateResource(d *schema.ResourceData, meta interface{}) error {
 	log.Println(d)
+	//Get client handle
+	client := meta.(*APIClient)
+
+	//A changed lease end is applied through the change lease action of the deployment
+	if d.HasChange("lease_end") {
+		if err := changeLease(d, client, d.Get("lease_end").(string)); err != nil {
+			return err
+		}
+	}
+
+	//A changed power state is applied through the power actions of the machines
+	if d.HasChange("power_state") || d.HasChange("component_power_state") {
+		err := changePowerState(d, client, d.Get("power_state").(string),
+			d.Get("component_power_state").(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+	}
+	return readResource(d, meta)
+}
+
+//pendingApprovalError - To report which approval a request is waiting for and by whom
+func pendingApprovalError(d *schema.ResourceData) error {
+	approval := "pre approval"
+	if d.Get("request_status").(string) == pendingPostApprovalPhase {
+		approval = "post approval"
+	}
+	var approvers []string
+	for _, approver := range d.Get("approvers").([]interface{}) {
+		approvers = append(approvers, approver.(string))
+	}
+	if len(approvers) == 0 {
+		return fmt.Errorf("request %s is waiting for %s, run terraform refresh once it is approved", d.Id(), approval)
+	}
+	return fmt.Errorf("request %s is waiting for %s by %s, run terraform refresh once it is approved",
+		d.Id(), approval, strings.Join(approvers, ", "))
+}
+
+//changePowerState - To run the power actions which bring every machine of the deployment
+//into its configured power state. A component power state overrides the deployment power state.
+func changePowerState(d *schema.ResourceData, client *APIClient, powerState string, componentPowerState map[string]interface{}) error {
+	if len(powerState) == 0 && len(componentPowerState) == 0 {
+		return nil
+	}
+
+	templateResources, errTemplate := client.GetResourceViews(d.Id())
+	if errTemplate != nil {
+		return fmt.Errorf("Resource view failed to load:  %v", errTemplate)
+	}
+
+	for _, resource := range templateResources.Content {
+		if !resource.isMachine() {
+			continue
+		}
+		targetState := powerState
+		if componentState, ok := componentPowerState[resource.componentName()]; ok {
+			targetState = componentState.(string)
+		}
+		if len(targetState) == 0 || strings.EqualFold(resource.Status, targetState) {
+			continue
+		}
+
+		requestID, err := client.ChangeMachinePowerState(resource, targetState)
+		if err != nil {
+			return fmt.Errorf("Power state change of %s failed: %v", resource.componentName(), err)
+		}
+		_, err = client.WaitForRequestCompletion(requestID, d.Get("wait_timeout").(int))
+		if err != nil {
+			return fmt.Errorf("Power state change of %s failed: %v", resource.componentName(), err)
+		}
+	}
+	return nil
+}
+
+//changeLease - To change the lease end of a deployment and wait for the change lease request
+func changeLease(d *schema.ResourceData, client *APIClient, leaseEnd string) error {
+	expirationDate, err := time.Parse(time.RFC3339, leaseEnd)
+	if err != nil {
+		return fmt.Errorf("Invalid lease_end %s: %v", leaseEnd, err)
+	}
+
+	templateResources, errTemplate := client.GetResourceViews(d.Id())
+	if errTemplate != nil {
+		return fmt.Errorf("Resource view failed to load:  %v", errTemplate)
+	}
+
+	requestID, err := client.PerformResourceOperation(templateResources, changeLeaseOperation, map[string]interface{}{
+		"provider-ExpirationDate": expirationDate.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("Change lease operation failed: %v", err)
+	}
+
+	_, err = client.WaitForRequestCompletion(requestID, d.Get("wait_timeout").(int))
+	if err != nil {
+		return fmt.Errorf("Change lease operation failed: %v", err)
+	}
+	d.Set("lease_end", leaseEnd)
 	return nil
 }
 
@@ -411,13 +763,93 @@ func readResource(d *schema.ResourceData, meta interface{}) error {
 
 	//Update resource request status in state file
 	d.Set("request_status", resourceTemplate.Phase)
+	d.Set("requested_for", resourceTemplate.RequestedFor)
+	d.Set("approval_status", resourceTemplate.ApprovalStatus)
 	//If request is failed then set failed message in state file
 	if resourceTemplate.Phase == "FAILED" {
 		d.Set("failed_message", resourceTemplate.RequestCompletion.CompletionDetails)
 	}
+	//A rejected request will never be provisioned, so remove it from state file
+	if resourceTemplate.Phase == "REJECTED" {
+		log.Printf("readResource->request %v was rejected, removing it from state\n", requestMachineID)
+		d.SetId("")
+		return nil
+	}
+
+	//Read who is asked to approve a pending request. The approvers are only informational
+	//and the requester may not be allowed to read the work items of other users.
+	var approvers []string
+	if isPendingApproval(resourceTemplate.Phase) {
+		approvers, errTemplate = client.GetRequestApprovers(requestMachineID)
+		if errTemplate != nil {
+			log.Printf("readResource->approvers of request %v failed to load: %v\n", requestMachineID, errTemplate)
+		}
+	}
+	d.Set("approvers", approvers)
+
+	//Read the lease of the provisioned deployment
+	if resourceTemplate.Phase == "SUCCESSFUL" {
+		templateResources, errTemplate := client.GetResourceViews(requestMachineID)
+		if errTemplate != nil {
+			return fmt.Errorf("Resource view failed to load:  %v", errTemplate)
+		}
+		for _, resource := range templateResources.Content {
+			if resource.ResourceType == deploymentResourceType {
+				d.Set("lease_end", resource.Lease.End)
+			}
+		}
+
+		//Read the power state of the deployment and of the configured components.
+		//The power state is empty when the machines are in different states.
+		componentPowerState := d.Get("component_power_state").(map[string]interface{})
+		deploymentState, componentStates := machinePowerStates(templateResources, componentPowerState)
+		d.Set("power_state", deploymentState)
+		for component := range componentPowerState {
+			componentPowerState[component] = componentStates[component]
+		}
+		d.Set("component_power_state", componentPowerState)
+	}
 	return nil
 }
 
+//machinePowerStates - To read the common power state of the machines of a deployment
+//and of the machines of each component. Machines of overridden components have their own
+//power state, so they are left out of the deployment power state.
+func machinePowerStates(template *ResourceViewsTemplate, overridden map[string]interface{}) (string, map[string]string) {
+	deploymentStates := make(map[string]bool)
+	componentStates := make(map[string]map[string]bool)
+	for _, resource := range template.Content {
+		if !resource.isMachine() {
+			continue
+		}
+		state := strings.ToLower(resource.Status)
+		if _, ok := overridden[resource.componentName()]; !ok {
+			deploymentStates[state] = true
+		}
+		if componentStates[resource.componentName()] == nil {
+			componentStates[resource.componentName()] = make(map[string]bool)
+		}
+		componentStates[resource.componentName()][state] = true
+	}
+
+	components := make(map[string]string)
+	for component, states := range componentStates {
+		components[component] = commonPowerState(states)
+	}
+	return commonPowerState(deploymentStates), components
+}
+
+//commonPowerState - the power state shared by a set of machines, empty if their states differ
+func commonPowerState(states map[string]bool) string {
+	if len(states) != 1 {
+		return ""
+	}
+	for state := range states {
+		return state
+	}
+	return ""
+}
+
 //Function use - To delete resources which are created by terraform and present in state file
 //Terraform call - terraform destroy
 func deleteResource(d *schema.ResourceData, meta interface{}) error {
@@ -432,7 +864,7 @@ func deleteResource(d *schema.ResourceData, meta interface{}) error {
 	}
 	//If resource create status is in_progress then skip delete call and through an exception
 	if d.Get("request_status").(string) != "SUCCESSFUL" {
-		if d.Get("request_status").(string) == "FAILED" {
+		if d.Get("request_status").(string) == "FAILED" || d.Get("request_status").(string) == "REJECTED" {
 			d.SetId("")
 			return nil
 		}
@@ -446,22 +878,31 @@ func deleteResource(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Resource view failed to load:  %v", errTemplate)
 	}
 
-	//Set a delete machine template function call.
-	//Which will fetch and return the delete machine template from the given template
-	DestroyMachineTemplate, resourceTemplate, errDestroyAction := client.GetDestroyActionTemplate(templateResources)
-	if errDestroyAction != nil {
-		if errDestroyAction.Error() == "resource is not created or not found" {
-			d.SetId("")
-			return fmt.Errorf("possibly resource got deleted outside terraform")
+	//Power off the machines first if requested
+	if d.Get("power_off_before_destroy").(bool) {
+		for _, resource := range templateResources.Content {
+			if !resource.isMachine() || strings.EqualFold(resource.Status, "off") {
+				continue
+			}
+			requestID, err := client.PowerOffMachine(resource)
+			if err != nil {
+				return fmt.Errorf("Power off of %s failed: %v", resource.componentName(), err)
+			}
+			_, err = client.WaitForRequestCompletion(requestID, d.Get("wait_timeout").(int))
+			if err != nil {
+				return fmt.Errorf("Power off of %s failed: %v", resource.componentName(), err)
+			}
 		}
-
-		return fmt.Errorf("Destory Machine action template failed to load: %v", errDestroyAction)
 	}
 
-	//Set a destroy machine REST call
-	_, errDestroyMachine := client.DestroyMachine(DestroyMachineTemplate, resourceTemplate)
-	//Raise an exception if error got while deleting resource
+	//Set a destroy deployment REST call
+	_, errDestroyMachine := client.DestroyMachine(templateResources)
 	if errDestroyMachine != nil {
+		//The destroy operation is not available once the deployment is gone
+		if _, notFound := errDestroyMachine.(operationNotFoundError); notFound {
+			d.SetId("")
+			return fmt.Errorf("possibly resource got deleted outside terraform")
+		}
 		return fmt.Errorf("Destory Machine machine operation failed: %v", errDestroyMachine)
 	}
 	//If resource got deleted then unset the resource ID from state file
@@ -469,65 +910,30 @@ func deleteResource(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-//DestroyMachine - To set resource destroy call
-func (c *APIClient) DestroyMachine(destroyTemplate *ActionTemplate, resourceViewTemplate *ResourceViewsTemplate) (*ActionResponseTemplate, error) {
-	//Get a destroy template URL from given resource template
-	var destroyactionURL string
-	destroyactionURL = getactionURL(resourceViewTemplate, "POST: {com.vmware.csp.component.cafe.composition@resource.action.deployment.destroy.name}")
-	//Raise an error if any exception raised while fetching delete resource URL
-	if len(destroyactionURL) == 0 {
-		return nil, fmt.Errorf("Resource is not created or not found")
-	}
-
-	actionResponse := new(ActionResponseTemplate)
-	apiError := new(APIError)
-
-	//Set a REST call with delete resource request and delete resource template as a data
-	resp, err := c.HTTPClient.New().Post(destroyactionURL).
-		BodyJSON(destroyTemplate).Receive(actionResponse, apiError)
-
-	if resp.StatusCode != 201 {
-		return nil, err
-	}
-
-	if !apiError.isEmpty() {
-		return nil, apiError
-	}
-
-	return actionResponse, nil
+//DestroyMachine - To run the destroy operation of a deployment and return the ID of the destroy request
+func (c *APIClient) DestroyMachine(resourceViewsTemplate *ResourceViewsTemplate) (string, error) {
+	return c.PerformResourceOperation(resourceViewsTemplate, destroyDeploymentOperation, nil)
 }
 
-//PowerOffMachine - To set resource power-off call
-func (c *APIClient) PowerOffMachine(powerOffTemplate *ActionTemplate, resourceViewTemplate *ResourceViewsTemplate) (*ActionResponseTemplate, error) {
-	//Get power-off resource URL from given template
-	var powerOffMachineactionURL string
-	powerOffMachineactionURL = getactionURL(resourceViewTemplate, "POST: {com.vmware.csp.component.iaas.proxy.provider@resource.action.name.machine.PowerOff}")
-	//Raise an exception if error got while fetching URL
-	if len(powerOffMachineactionURL) == 0 {
-		return nil, fmt.Errorf("resource is not created or not found")
-	}
-
-	actionResponse := new(ActionResponseTemplate)
-	apiError := new(APIError)
-
-	//Set a rest call to power-off the resource with resource power-off template as a data
-	response, err := c.HTTPClient.New().Post(powerOffMachineactionURL).
-		BodyJSON(powerOffTemplate).Receive(actionResponse, apiError)
+//PowerOffMachine - To set machine power-off call and return the ID of the power-off request
+func (c *APIClient) PowerOffMachine(machine ResourceView) (string, error) {
+	return c.ChangeMachinePowerState(machine, "off")
+}
 
-	response.Close = true
-	if response.StatusCode == 201 {
-		return actionResponse, nil
+//ChangeMachinePowerState - To run the power action of a machine which leads to the given
+//power state and return the ID of the action request
+func (c *APIClient) ChangeMachinePowerState(machine ResourceView, powerState string) (string, error) {
+	if strings.EqualFold(machine.Status, "off") && powerState == "suspended" {
+		return "", fmt.Errorf("a machine which is powered off cannot be suspended")
 	}
-
-	if !apiError.isEmpty() {
-		return nil, apiError
+	operationID, ok := machinePowerActions[powerState]
+	if !ok {
+		return "", fmt.Errorf("unknown power state %s, expected one of %v", powerState, powerStates)
 	}
 
-	if err != nil {
-		return nil, err
-	}
-
-	return nil, err
+	//Limit the operation lookup to the given machine
+	resourceViewTemplate := &ResourceViewsTemplate{Content: []ResourceView{machine}}
+	return c.PerformResourceOperation(resourceViewTemplate, operationID, nil)
 }
 
 //GetRequestStatus - To read request status of resource
@@ -538,16 +944,43 @@ func (c *APIClient) GetRequestStatus(ResourceID string) (*RequestStatusView, err
 	RequestStatusViewTemplate := new(RequestStatusView)
 	apiError := new(APIError)
 	//Set a REST call and fetch a resource request status
-	_, err := c.HTTPClient.New().Get(path).Receive(RequestStatusViewTemplate, apiError)
+	resp, err := c.HTTPClient.New().Get(path).Receive(RequestStatusViewTemplate, apiError)
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode == 404 {
+		return nil, NotFoundError{"request", ResourceID}
+	}
 	if !apiError.isEmpty() {
 		return nil, apiError
 	}
 	return RequestStatusViewTemplate, nil
 }
 
+//WaitForRequestCompletion - To poll the status of a request until it is completed
+//or the wait timeout given in minutes expires
+func (c *APIClient) WaitForRequestCompletion(requestID string, waitTimeout int) (*RequestStatusView, error) {
+	deadline := time.Now().Add(time.Duration(waitTimeout) * time.Minute)
+	for {
+		requestStatus, err := c.GetRequestStatus(requestID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch requestStatus.Phase {
+		case "SUCCESSFUL":
+			return requestStatus, nil
+		case "FAILED", "REJECTED":
+			return requestStatus, fmt.Errorf("request %s %s: %s", requestID,
+				strings.ToLower(requestStatus.Phase), requestStatus.RequestCompletion.CompletionDetails)
+		}
+		if time.Now().After(deadline) {
+			return requestStatus, fmt.Errorf("request %s is still in progress", requestID)
+		}
+		time.Sleep(requestPollInterval)
+	}
+}
+
 //GetResourceViews - To read resource configuration
 func (c *APIClient) GetResourceViews(ResourceID string) (*ResourceViewsTemplate, error) {
 	//Form an URL to fetch resource list view
@@ -0,0 +1,180 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+//ResourceAction - use to set resource action fields
+func ResourceAction() *schema.Resource {
+	return &schema.Resource{
+		Create: createResourceAction,
+		Read:   readResourceAction,
+		Update: updateResourceAction,
+		Delete: deleteResourceAction,
+		Schema: resourceActionSchema(),
+	}
+}
+
+//resourceActionSchema - An action runs once against an existing deployment or machine,
+//so every argument except wait_timeout forces a new action request.
+func resourceActionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"resource_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"action": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+		"reasons": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+		"data": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem:     schema.TypeString,
+		},
+		"wait_timeout": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  15,
+		},
+		"request_status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"failed_message": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+//Function use - to run a day-2 action against a resource and wait for its request
+//Terraform call - terraform apply
+func createResourceAction(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	//Collect the user data for the action template
+	data := make(map[string]interface{})
+	for field, value := range d.Get("data").(map[string]interface{}) {
+		data[field] = value
+	}
+	if reasons, ok := d.GetOk("reasons"); ok {
+		data["reasons"] = reasons.(string)
+	}
+
+	requestID, err := client.SubmitResourceAction(d.Get("resource_id").(string),
+		d.Get("action").(string), d.Get("description").(string), data)
+	if err != nil {
+		return fmt.Errorf("Action %s failed: %v", d.Get("action").(string), err)
+	}
+	d.SetId(requestID)
+
+	requestStatus, err := client.WaitForRequestCompletion(requestID, d.Get("wait_timeout").(int))
+	if requestStatus != nil {
+		d.Set("request_status", requestStatus.Phase)
+		d.Set("failed_message", requestStatus.RequestCompletion.CompletionDetails)
+	}
+	if err != nil {
+		if requestStatus != nil && requestStatus.Phase == "FAILED" {
+			d.SetId("")
+		}
+		return fmt.Errorf("Action %s failed: %v", d.Get("action").(string), err)
+	}
+	return nil
+}
+
+//Function use - To read the status of the action request
+//Terraform call - terraform refresh
+func readResourceAction(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	requestStatus, err := client.GetRequestStatus(d.Id())
+	if err != nil {
+		return fmt.Errorf("Resource view failed to load:  %v", err)
+	}
+	d.Set("request_status", requestStatus.Phase)
+	d.Set("failed_message", requestStatus.RequestCompletion.CompletionDetails)
+	return nil
+}
+
+//Function use - To keep a changed wait_timeout, the action is not run again
+//Terraform call - terraform apply
+func updateResourceAction(d *schema.ResourceData, meta interface{}) error {
+	return readResourceAction(d, meta)
+}
+
+//Function use - An action can not be undone, so only remove it from the state file
+//Terraform call - terraform destroy
+func deleteResourceAction(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+//GetResourceView - To read a single deployment or machine resource with its action links
+func (c *APIClient) GetResourceView(resourceID string) (*ResourceView, error) {
+	path := fmt.Sprintf("catalog-service/api/consumer/resourceViews/%s", resourceID)
+	resourceView := new(ResourceView)
+	apiError := new(APIError)
+	//Set a REST call to fetch resource view data
+	_, err := c.HTTPClient.New().Get(path).Receive(resourceView, apiError)
+	if err != nil {
+		return nil, err
+	}
+	if !apiError.isEmpty() {
+		return nil, apiError
+	}
+	return resourceView, nil
+}
+
+//SubmitResourceAction - To find an operation of a resource by ID or display name, fill its template
+//with the given description and data and submit it. Returns the ID of the action request.
+func (c *APIClient) SubmitResourceAction(resourceID string, operationName string, description string, data map[string]interface{}) (string, error) {
+	resourceView, err := c.GetResourceView(resourceID)
+	if err != nil {
+		return "", fmt.Errorf("Resource view failed to load:  %v", err)
+	}
+
+	componentActions, err := c.GetComponentActions(
+		&ResourceViewsTemplate{Content: []ResourceView{*resourceView}}, operationName)
+	if err != nil {
+		return "", err
+	}
+
+	actionTemplate, err := c.GetComponentActionTemplate(componentActions[0])
+	if err != nil {
+		return "", fmt.Errorf("action template failed to load: %v", err)
+	}
+
+	//Fill the action template with the user data
+	if len(description) > 0 {
+		actionTemplate.Description = description
+	}
+	for field, value := range data {
+		var replaced bool
+		actionTemplate.Data, replaced = changeTemplateValue(actionTemplate.Data, field, value)
+		if !replaced {
+			actionTemplate.Data[field] = value
+		}
+	}
+	log.Printf("SubmitResourceAction->actionTemplate %v\n", actionTemplate)
+
+	return c.PerformAction(actionTemplate, componentActions[0].RequestURL)
+}
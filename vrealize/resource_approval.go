@@ -0,0 +1,110 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+//ResourceApproval - use to set approval resource fields
+func ResourceApproval() *schema.Resource {
+	return &schema.Resource{
+		Create: createApproval,
+		Read:   readApproval,
+		Delete: deleteApproval,
+		Schema: approvalSchema(),
+	}
+}
+
+//approvalSchema - An approval decision is submitted once, so every argument forces a new decision
+func approvalSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"request_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"action": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"approve", "reject"}, false),
+		},
+		"justification": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+		"work_item_number": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"request_status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+//Function use - to approve or reject the pending approval of a request
+//Terraform call - terraform apply
+func createApproval(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+	requestID := d.Get("request_id").(string)
+
+	requestStatus, err := client.GetRequestStatus(requestID)
+	if err != nil {
+		return fmt.Errorf("Request status failed to load: %v", err)
+	}
+	if !isPendingApproval(requestStatus.Phase) {
+		return fmt.Errorf("request %s is not waiting for approval, its status is %s", requestID, requestStatus.Phase)
+	}
+
+	workItems, err := client.GetRequestWorkItems(requestID)
+	if err != nil {
+		return fmt.Errorf("Work items failed to load: %v", err)
+	}
+	workItem := findPendingApprovalWorkItem(workItems)
+	if workItem == nil {
+		return fmt.Errorf("no approval work item found for request %s", requestID)
+	}
+
+	action := d.Get("action").(string)
+	err = client.CompleteWorkItem(workItem.ID, workItemActions[action], d.Get("justification").(string))
+	if err != nil {
+		return fmt.Errorf("Work item %d could not be completed with %s: %v", workItem.WorkItemNumber, action, err)
+	}
+	d.SetId(workItem.ID)
+	d.Set("work_item_number", workItem.WorkItemNumber)
+	log.Printf("createApproval->work item %v %v\n", workItem.ID, action)
+	return readApproval(d, meta)
+}
+
+//Function use - To read the status of the approved or rejected request
+//Terraform call - terraform refresh
+func readApproval(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	requestStatus, err := client.GetRequestStatus(d.Get("request_id").(string))
+	if isNotFound(err) {
+		log.Printf("readApproval->%v, removing it from state\n", err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Request status failed to load: %v", err)
+	}
+	d.Set("request_status", requestStatus.Phase)
+	return nil
+}
+
+//Function use - An approval decision can not be undone, so only remove it from the state file
+//Terraform call - terraform destroy
+func deleteApproval(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
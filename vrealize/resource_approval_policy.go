@@ -0,0 +1,274 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+//approvalPhaseArguments - resource arguments holding the levels of each approval phase
+var approvalPhaseArguments = map[string]string{
+	"pre_approval_level":  preApprovalPhaseSuffix,
+	"post_approval_level": postApprovalPhaseSuffix,
+}
+
+//ResourceApprovalPolicy - use to set approval policy resource fields
+func ResourceApprovalPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: createApprovalPolicy,
+		Read:   readApprovalPolicy,
+		Update: updateApprovalPolicy,
+		Delete: deleteApprovalPolicy,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: approvalPolicySchema(),
+	}
+}
+
+//approvalPolicySchema - Levels run in the order they are given. A level with conditions only
+//applies to requests matching all of them, a level without conditions is always required.
+func approvalPolicySchema() map[string]*schema.Schema {
+	approvalLevels := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"description": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"approval_mode": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      approvalModeAny,
+						ValidateFunc: validation.StringInSlice([]string{approvalModeAny, approvalModeAll}, false),
+					},
+					"approvers": {
+						Type:     schema.TypeSet,
+						Optional: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+						Set:      schema.HashString,
+					},
+					"approver_groups": {
+						Type:     schema.TypeSet,
+						Optional: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+						Set:      schema.HashString,
+					},
+					"condition": conditionSchema(),
+				},
+			},
+		}
+	}
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"policy_type": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  catalogRequestPolicyType,
+		},
+		"status": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  approvalPolicyPublished,
+			ValidateFunc: validation.StringInSlice([]string{
+				approvalPolicyPublished, approvalPolicyDraft, approvalPolicyRetired}, false),
+		},
+		"pre_approval_level":  approvalLevels(),
+		"post_approval_level": approvalLevels(),
+	}
+}
+
+//Function use - to create an approval policy
+//Terraform call - terraform apply
+func createApprovalPolicy(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	policy := &ApprovalPolicy{}
+	if err := setApprovalPolicyFields(d, policy); err != nil {
+		return err
+	}
+
+	created, err := client.CreateApprovalPolicy(policy)
+	if err != nil {
+		return fmt.Errorf("Approval policy creation failed: %v", err)
+	}
+	d.SetId(created.ID)
+	log.Printf("createApprovalPolicy->id %v\n", created.ID)
+	return readApprovalPolicy(d, meta)
+}
+
+//Function use - To read an approval policy and the levels of its phases
+//Terraform call - terraform refresh
+func readApprovalPolicy(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	policy, err := client.GetApprovalPolicy(d.Id())
+	if isNotFound(err) {
+		log.Printf("readApprovalPolicy->%v, removing it from state\n", err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Approval policy failed to load: %v", err)
+	}
+
+	d.Set("name", policy.Name)
+	d.Set("description", policy.Description)
+	d.Set("policy_type", policy.PolicyTypeID)
+	d.Set("status", policy.State)
+
+	for argument, suffix := range approvalPhaseArguments {
+		var levels []map[string]interface{}
+		for _, phase := range policy.Phases {
+			if !strings.HasSuffix(phase.PhaseTypeID, suffix) {
+				continue
+			}
+			sort.Slice(phase.Levels, func(i, j int) bool {
+				return phase.Levels[i].LevelNumber < phase.Levels[j].LevelNumber
+			})
+			for _, level := range phase.Levels {
+				levels = append(levels, flattenApprovalLevel(level))
+			}
+		}
+		d.Set(argument, levels)
+	}
+	return nil
+}
+
+//Function use - To update an approval policy
+//Terraform call - terraform apply
+func updateApprovalPolicy(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	policy := &ApprovalPolicy{ID: d.Id()}
+	if err := setApprovalPolicyFields(d, policy); err != nil {
+		return err
+	}
+	if err := client.UpdateApprovalPolicy(policy); err != nil {
+		return fmt.Errorf("Approval policy update failed: %v", err)
+	}
+	return readApprovalPolicy(d, meta)
+}
+
+//Function use - To retire and delete an approval policy
+//Terraform call - terraform destroy
+func deleteApprovalPolicy(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	err := client.DeleteApprovalPolicy(d.Id())
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Approval policy deletion failed: %v", err)
+	}
+	d.SetId("")
+	return nil
+}
+
+//setApprovalPolicyFields - To copy the configured fields and levels into the approval policy
+func setApprovalPolicyFields(d *schema.ResourceData, policy *ApprovalPolicy) error {
+	policy.Name = d.Get("name").(string)
+	policy.Description = d.Get("description").(string)
+	policy.PolicyTypeID = d.Get("policy_type").(string)
+	policy.State = d.Get("status").(string)
+
+	policy.Phases = []ApprovalPhase{}
+	phaseNames := map[string]string{
+		preApprovalPhaseSuffix:  "Pre Approval",
+		postApprovalPhaseSuffix: "Post Approval",
+	}
+	for _, argument := range []string{"pre_approval_level", "post_approval_level"} {
+		suffix := approvalPhaseArguments[argument]
+		phase := ApprovalPhase{
+			Name:        phaseNames[suffix],
+			PhaseTypeID: policy.PolicyTypeID + suffix,
+			Levels:      []ApprovalLevel{},
+		}
+		for i, item := range d.Get(argument).([]interface{}) {
+			level, err := expandApprovalLevel(item.(map[string]interface{}))
+			if err != nil {
+				return fmt.Errorf("Invalid %s: %v", argument, err)
+			}
+			level.LevelNumber = i + 1
+			phase.Levels = append(phase.Levels, level)
+		}
+		policy.Phases = append(policy.Phases, phase)
+	}
+	return nil
+}
+
+//expandApprovalLevel - To build an approval level from its configuration block
+func expandApprovalLevel(config map[string]interface{}) (ApprovalLevel, error) {
+	level := ApprovalLevel{
+		Name:         config["name"].(string),
+		Description:  config["description"].(string),
+		ApprovalMode: config["approval_mode"].(string),
+		ApproverType: specificApproverType,
+		Approvers:    []Approver{},
+	}
+
+	approverTypes := map[string]string{
+		"approvers":       entitlementUserPrincipal,
+		"approver_groups": entitlementGroupPrincipal,
+	}
+	for argument, approverType := range approverTypes {
+		for _, approver := range config[argument].(*schema.Set).List() {
+			if _, err := parsePrincipalID(approver.(string)); err != nil {
+				return level, err
+			}
+			level.Approvers = append(level.Approvers, Approver{Type: approverType, Value: approver.(string)})
+		}
+	}
+	if len(level.Approvers) == 0 {
+		return level, fmt.Errorf("level %s has no approvers", level.Name)
+	}
+
+	conditions := expandConditions(config["condition"].([]interface{}))
+	level.AlwaysRequired = len(conditions) == 0
+	if !level.AlwaysRequired {
+		level.Condition = conditionClause(conditions)
+	}
+	return level, nil
+}
+
+//flattenApprovalLevel - To convert an approval level into its configuration block
+func flattenApprovalLevel(level ApprovalLevel) map[string]interface{} {
+	var approvers, approverGroups []interface{}
+	for _, approver := range level.Approvers {
+		if approver.Type == entitlementGroupPrincipal {
+			approverGroups = append(approverGroups, approver.Value)
+		} else {
+			approvers = append(approvers, approver.Value)
+		}
+	}
+
+	return map[string]interface{}{
+		"name":            level.Name,
+		"description":     level.Description,
+		"approval_mode":   level.ApprovalMode,
+		"approvers":       schema.NewSet(schema.HashString, approvers),
+		"approver_groups": schema.NewSet(schema.HashString, approverGroups),
+		"condition":       flattenConditions(level.Condition),
+	}
+}
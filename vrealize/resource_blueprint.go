@@ -0,0 +1,183 @@
+package vrealize
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+//ResourceBlueprint - use to set composite blueprint resource fields
+func ResourceBlueprint() *schema.Resource {
+	return &schema.Resource{
+		Create: createBlueprint,
+		Read:   readBlueprint,
+		Update: updateBlueprint,
+		Delete: deleteBlueprint,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: blueprintSchema(),
+	}
+}
+
+//blueprintSchema - The content is kept in the state as normalized JSON
+func blueprintSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"content": {
+			Type:         schema.TypeString,
+			Required:     true,
+			StateFunc:    blueprintContentStateFunc,
+			ValidateFunc: validateBlueprintContent,
+		},
+		"published": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+//blueprintContentStateFunc - Content which can not be parsed is kept as is, validation reports it
+func blueprintContentStateFunc(value interface{}) string {
+	normalized, err := normalizeBlueprintContent(value.(string))
+	if err != nil {
+		return value.(string)
+	}
+	return normalized
+}
+
+//validateBlueprintContent - To check the content is a YAML or JSON blueprint with an id
+func validateBlueprintContent(value interface{}, key string) ([]string, []error) {
+	if _, err := parseBlueprintContent(value.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %v", key, err)}
+	}
+	return nil, nil
+}
+
+//Function use - to create a composite blueprint and publish it
+//Terraform call - terraform apply
+func createBlueprint(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	blueprint, err := parseBlueprintContent(d.Get("content").(string))
+	if err != nil {
+		return err
+	}
+	blueprintID := blueprint["id"].(string)
+
+	if err := client.CreateBlueprint(blueprint); err != nil {
+		return fmt.Errorf("Blueprint creation failed: %v", err)
+	}
+	d.SetId(blueprintID)
+	log.Printf("createBlueprint->id %v\n", blueprintID)
+
+	if d.Get("published").(bool) {
+		if err := client.SetBlueprintStatus(blueprintID, blueprintPublished); err != nil {
+			return fmt.Errorf("Blueprint publishing failed: %v", err)
+		}
+	}
+	return readBlueprint(d, meta)
+}
+
+//Function use - To read a composite blueprint, the content is only replaced
+//when the blueprint no longer matches it
+//Terraform call - terraform refresh
+func readBlueprint(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	blueprint, err := client.GetBlueprint(d.Id())
+	if isNotFound(err) {
+		log.Printf("readBlueprint->%v, removing it from state\n", err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Blueprint failed to load: %v", err)
+	}
+
+	status, _ := blueprint["status"].(string)
+	name, _ := blueprint["name"].(string)
+	d.Set("status", status)
+	d.Set("name", name)
+	d.Set("published", status == blueprintPublished)
+
+	configured, err := parseBlueprintContent(d.Get("content").(string))
+	if err != nil || !blueprintContentContains(blueprint, configured) {
+		log.Printf("readBlueprint->blueprint %s differs from the configured content\n", d.Id())
+		content, err := json.Marshal(blueprint)
+		if err != nil {
+			return err
+		}
+		d.Set("content", string(content))
+	}
+	return nil
+}
+
+//Function use - To update the content of a composite blueprint and publish or unpublish it
+//Terraform call - terraform apply
+func updateBlueprint(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	if d.HasChange("content") {
+		blueprint, err := parseBlueprintContent(d.Get("content").(string))
+		if err != nil {
+			return err
+		}
+		//The id identifies the blueprint, so like a forced new resource the blueprint is
+		//deleted and then created with the new id
+		if blueprintID := blueprint["id"].(string); blueprintID != d.Id() {
+			log.Printf("updateBlueprint->replacing blueprint %s by %s\n", d.Id(), blueprintID)
+			if err := deleteBlueprint(d, meta); err != nil {
+				return err
+			}
+			return createBlueprint(d, meta)
+		}
+		if err := client.UpdateBlueprint(d.Id(), blueprint); err != nil {
+			return fmt.Errorf("Blueprint update failed: %v", err)
+		}
+	}
+
+	if d.HasChange("published") {
+		status := blueprintDraft
+		if d.Get("published").(bool) {
+			status = blueprintPublished
+		}
+		if err := client.SetBlueprintStatus(d.Id(), status); err != nil {
+			return fmt.Errorf("Blueprint status change to %s failed: %v", status, err)
+		}
+	}
+	return readBlueprint(d, meta)
+}
+
+//Function use - To unpublish and delete a composite blueprint
+//Terraform call - terraform destroy
+func deleteBlueprint(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	if d.Get("status").(string) == blueprintPublished {
+		if err := client.SetBlueprintStatus(d.Id(), blueprintDraft); err != nil && !isNotFound(err) {
+			return fmt.Errorf("Blueprint unpublishing failed: %v", err)
+		}
+	}
+
+	err := client.DeleteBlueprint(d.Id())
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Blueprint deletion failed: %v", err)
+	}
+	d.SetId("")
+	return nil
+}
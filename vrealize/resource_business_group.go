@@ -0,0 +1,201 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+//Business group extension data keys used by IaaS
+const (
+	businessGroupManagerEmailKey  = "iaas-manager-emails"
+	businessGroupMachinePrefixKey = "iaas-machine-prefix"
+	businessGroupADContainerKey   = "iaas-ad-container"
+)
+
+//businessGroupRoleArguments - resource arguments holding the principals of each business group role
+var businessGroupRoleArguments = map[string]string{
+	"managers":            businessGroupManagerRole,
+	"support_users":       businessGroupSupportRole,
+	"users":               businessGroupUserRole,
+	"shared_access_users": businessGroupSharedAccessUserRole,
+}
+
+//ResourceBusinessGroup - use to set business group resource fields
+func ResourceBusinessGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: createBusinessGroup,
+		Read:   readBusinessGroup,
+		Update: updateBusinessGroup,
+		Delete: deleteBusinessGroup,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: businessGroupSchema(),
+	}
+}
+
+//businessGroupSchema - Principals of the roles are given as name@domain
+func businessGroupSchema() map[string]*schema.Schema {
+	principalSet := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Set:      schema.HashString,
+		}
+	}
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"manager_email": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"machine_prefix_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"ad_container": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"managers":            principalSet(),
+		"support_users":       principalSet(),
+		"users":               principalSet(),
+		"shared_access_users": principalSet(),
+	}
+}
+
+//Function use - to create a business group and assign its roles
+//Terraform call - terraform apply
+func createBusinessGroup(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	businessGroup := &BusinessGroup{}
+	setBusinessGroupFields(d, businessGroup)
+
+	created, err := client.CreateBusinessGroup(businessGroup)
+	if err != nil {
+		return fmt.Errorf("Business group creation failed: %v", err)
+	}
+	d.SetId(created.ID)
+	log.Printf("createBusinessGroup->id %v\n", created.ID)
+
+	for argument, role := range businessGroupRoleArguments {
+		if err := setBusinessGroupRole(d, client, argument, role); err != nil {
+			return err
+		}
+	}
+	return readBusinessGroup(d, meta)
+}
+
+//Function use - To read a business group and the principals of its roles
+//Terraform call - terraform refresh
+func readBusinessGroup(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	businessGroup, err := client.GetBusinessGroup(d.Id())
+	if isNotFound(err) {
+		log.Printf("readBusinessGroup->%v, removing it from state\n", err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Business group failed to load: %v", err)
+	}
+
+	d.Set("name", businessGroup.Name)
+	d.Set("description", businessGroup.Description)
+	d.Set("manager_email", businessGroup.ExtensionData.extensionValue(businessGroupManagerEmailKey))
+	d.Set("machine_prefix_id", businessGroup.ExtensionData.extensionValue(businessGroupMachinePrefixKey))
+	d.Set("ad_container", businessGroup.ExtensionData.extensionValue(businessGroupADContainerKey))
+
+	for argument, role := range businessGroupRoleArguments {
+		principals, err := client.GetBusinessGroupRolePrincipals(d.Id(), role)
+		if err != nil {
+			return fmt.Errorf("Business group role %s failed to load: %v", role, err)
+		}
+		d.Set(argument, principalNames(principals))
+	}
+	return nil
+}
+
+//Function use - To update a business group and the roles whose principals changed
+//Terraform call - terraform apply
+func updateBusinessGroup(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	if d.HasChange("name") || d.HasChange("description") || d.HasChange("manager_email") ||
+		d.HasChange("machine_prefix_id") || d.HasChange("ad_container") {
+		//Keep the extension data not managed by terraform
+		businessGroup, err := client.GetBusinessGroup(d.Id())
+		if err != nil {
+			return fmt.Errorf("Business group failed to load: %v", err)
+		}
+		setBusinessGroupFields(d, businessGroup)
+		if err := client.UpdateBusinessGroup(businessGroup); err != nil {
+			return fmt.Errorf("Business group update failed: %v", err)
+		}
+	}
+
+	for argument, role := range businessGroupRoleArguments {
+		if !d.HasChange(argument) {
+			continue
+		}
+		if err := setBusinessGroupRole(d, client, argument, role); err != nil {
+			return err
+		}
+	}
+	return readBusinessGroup(d, meta)
+}
+
+//Function use - To delete a business group
+//Terraform call - terraform destroy
+func deleteBusinessGroup(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	err := client.DeleteBusinessGroup(d.Id())
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Business group deletion failed: %v", err)
+	}
+	d.SetId("")
+	return nil
+}
+
+//setBusinessGroupFields - To copy the configured fields into the business group
+func setBusinessGroupFields(d *schema.ResourceData, businessGroup *BusinessGroup) {
+	businessGroup.Name = d.Get("name").(string)
+	businessGroup.Description = d.Get("description").(string)
+	businessGroup.ExtensionData.setExtensionValue(businessGroupManagerEmailKey, d.Get("manager_email").(string))
+	businessGroup.ExtensionData.setExtensionValue(businessGroupMachinePrefixKey, d.Get("machine_prefix_id").(string))
+	businessGroup.ExtensionData.setExtensionValue(businessGroupADContainerKey, d.Get("ad_container").(string))
+}
+
+//setBusinessGroupRole - To assign the configured principals to a business group role
+func setBusinessGroupRole(d *schema.ResourceData, client *APIClient, argument string, role string) error {
+	var principals []PrincipalID
+	for _, principal := range d.Get(argument).(*schema.Set).List() {
+		principalID, err := parsePrincipalID(principal.(string))
+		if err != nil {
+			return fmt.Errorf("Invalid %s: %v", argument, err)
+		}
+		principals = append(principals, principalID)
+	}
+
+	if err := client.SetBusinessGroupRolePrincipals(d.Id(), role, principals); err != nil {
+		return fmt.Errorf("Business group role %s assignment failed: %v", role, err)
+	}
+	return nil
+}
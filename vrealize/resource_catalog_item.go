@@ -0,0 +1,179 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+//ResourceCatalogItem - use to set catalog item resource fields
+func ResourceCatalogItem() *schema.Resource {
+	return &schema.Resource{
+		Create: createCatalogItem,
+		Read:   readCatalogItem,
+		Update: updateCatalogItem,
+		Delete: deleteCatalogItem,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: catalogItemSchema(),
+	}
+}
+
+//catalogItemSchema - Catalog items are created by publishing a blueprint, so the resource
+//takes over an existing catalog item given by its id or name. Arguments which are not set
+//keep the value of the catalog item.
+func catalogItemSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"catalog_item_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"catalog_name"},
+		},
+		"catalog_name": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ConflictsWith:    []string{"catalog_item_id"},
+			DiffSuppressFunc: suppressImportedCatalogName,
+		},
+		"catalog_name_match": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			Default:          catalogNameMatchExact,
+			ValidateFunc:     validation.StringInSlice(catalogNameMatchTypes, false),
+			DiffSuppressFunc: suppressImportedCatalogName,
+		},
+		"status": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				catalogItemPublished, catalogItemRetired, catalogItemStaging}, false),
+		},
+		"service_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"icon_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+//Function use - to take over a published catalog item and apply the configured settings
+//Terraform call - terraform apply
+func createCatalogItem(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	catalogItemID := d.Get("catalog_item_id").(string)
+	if catalogName, ok := d.GetOk("catalog_name"); ok {
+		var err error
+		catalogItemID, err = client.readCatalogIDByName(catalogName.(string), d.Get("catalog_name_match").(string))
+		if err != nil {
+			return err
+		}
+	}
+	if len(catalogItemID) == 0 {
+		return fmt.Errorf("Either catalog_name or catalog_item_id should be present in given configuration")
+	}
+
+	d.SetId(catalogItemID)
+	log.Printf("createCatalogItem->id %v\n", catalogItemID)
+	return updateCatalogItem(d, meta)
+}
+
+//Function use - To read the status, service, description and icon of a catalog item
+//Terraform call - terraform refresh
+func readCatalogItem(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	catalogItem, err := client.GetCatalogItemDefinition(d.Id())
+	if isNotFound(err) {
+		log.Printf("readCatalogItem->%v, removing it from state\n", err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Catalog item failed to load: %v", err)
+	}
+
+	serviceRef, _ := catalogItem["serviceRef"].(map[string]interface{})
+	d.Set("catalog_item_id", d.Id())
+	d.Set("name", catalogItem["name"])
+	d.Set("status", catalogItem["status"])
+	d.Set("description", catalogItem["description"])
+	d.Set("icon_id", catalogItem["iconId"])
+	d.Set("service_id", serviceRef["id"])
+	return nil
+}
+
+//suppressImportedCatalogName - An imported catalog item is known by its id only, so setting
+//the name it would be looked up by does not create a new resource
+func suppressImportedCatalogName(k, old, new string, d *schema.ResourceData) bool {
+	return old == "" && d.Id() != ""
+}
+
+//Function use - To change the status, service, description and icon of a catalog item
+//Terraform call - terraform apply
+func updateCatalogItem(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	catalogItem, err := client.GetCatalogItemDefinition(d.Id())
+	if err != nil {
+		return fmt.Errorf("Catalog item failed to load: %v", err)
+	}
+
+	//Only the configured arguments are changed
+	var changed bool
+	for argument, field := range map[string]string{
+		"status":      "status",
+		"description": "description",
+		"icon_id":     "iconId",
+	} {
+		if value, ok := d.GetOk(argument); ok && catalogItem[field] != value {
+			catalogItem[field] = value
+			changed = true
+		}
+	}
+	serviceRef, _ := catalogItem["serviceRef"].(map[string]interface{})
+	if serviceID, ok := d.GetOk("service_id"); ok && serviceRef["id"] != serviceID {
+		catalogItem["serviceRef"] = map[string]interface{}{"id": serviceID}
+		changed = true
+	}
+
+	if changed {
+		if err := client.UpdateCatalogItemDefinition(d.Id(), catalogItem); err != nil {
+			return fmt.Errorf("Catalog item update failed: %v", err)
+		}
+	}
+	return readCatalogItem(d, meta)
+}
+
+//Function use - Catalog items are removed by deleting their blueprint, so only remove it from
+//the state file and keep the catalog item as it is
+//Terraform call - terraform destroy
+func deleteCatalogItem(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,211 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+//ResourceCatalogService - use to set catalog service resource fields
+func ResourceCatalogService() *schema.Resource {
+	return &schema.Resource{
+		Create: createCatalogService,
+		Read:   readCatalogService,
+		Update: updateCatalogService,
+		Delete: deleteCatalogService,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: catalogServiceSchema(),
+	}
+}
+
+//catalogServiceSchema - The owner is a user and the support team a group, both given as name@domain
+func catalogServiceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"status": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      catalogServiceActive,
+			ValidateFunc: validation.StringInSlice([]string{catalogServiceActive, catalogServiceInactive}, false),
+		},
+		"owner": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"support_team": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"hours": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"start_time": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validateServiceTime,
+					},
+					"end_time": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validateServiceTime,
+					},
+				},
+			},
+		},
+	}
+}
+
+//validateServiceTime - Service hours are given as HH:MM
+func validateServiceTime(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.Parse("15:04", v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a time of day as HH:MM, e.g. 08:30: %v", k, err))
+	}
+	return
+}
+
+//Function use - to create a catalog service
+//Terraform call - terraform apply
+func createCatalogService(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	service := &CatalogService{}
+	if err := setCatalogServiceFields(d, client, service); err != nil {
+		return err
+	}
+
+	created, err := client.CreateCatalogService(service)
+	if err != nil {
+		return fmt.Errorf("Catalog service creation failed: %v", err)
+	}
+	d.SetId(created.ID)
+	log.Printf("createCatalogService->id %v\n", created.ID)
+	return readCatalogService(d, meta)
+}
+
+//Function use - To read a catalog service
+//Terraform call - terraform refresh
+func readCatalogService(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	service, err := client.GetCatalogService(d.Id())
+	if isNotFound(err) {
+		log.Printf("readCatalogService->%v, removing it from state\n", err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Catalog service failed to load: %v", err)
+	}
+
+	d.Set("name", service.Name)
+	d.Set("description", service.Description)
+	d.Set("status", service.Status)
+
+	var owner, supportTeam string
+	if service.Owner != nil {
+		owner = service.Owner.Ref
+	}
+	if service.SupportTeam != nil {
+		supportTeam = service.SupportTeam.Ref
+	}
+	d.Set("owner", owner)
+	d.Set("support_team", supportTeam)
+
+	var hours []map[string]interface{}
+	if service.Hours != nil {
+		hours = append(hours, map[string]interface{}{
+			"start_time": service.Hours.StartTime,
+			"end_time":   service.Hours.EndTime,
+		})
+	}
+	d.Set("hours", hours)
+	return nil
+}
+
+//Function use - To update a catalog service
+//Terraform call - terraform apply
+func updateCatalogService(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	//Start from the current service so its version, icon, change window and new duration are kept
+	service, err := client.GetCatalogService(d.Id())
+	if err != nil {
+		return fmt.Errorf("Catalog service failed to load: %v", err)
+	}
+	if err := setCatalogServiceFields(d, client, service); err != nil {
+		return err
+	}
+	if err := client.UpdateCatalogService(service); err != nil {
+		return fmt.Errorf("Catalog service update failed: %v", err)
+	}
+	return readCatalogService(d, meta)
+}
+
+//Function use - To deactivate and delete a catalog service
+//Terraform call - terraform destroy
+func deleteCatalogService(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	err := client.DeleteCatalogService(d.Id())
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Catalog service deletion failed: %v", err)
+	}
+	d.SetId("")
+	return nil
+}
+
+//setCatalogServiceFields - To copy the configured fields into the catalog service
+func setCatalogServiceFields(d *schema.ResourceData, client *APIClient, service *CatalogService) error {
+	service.Name = d.Get("name").(string)
+	service.Description = d.Get("description").(string)
+	service.Status = d.Get("status").(string)
+
+	var err error
+	if service.Owner, err = catalogServicePrincipal(d, client, "owner", entitlementUserPrincipal); err != nil {
+		return err
+	}
+	if service.SupportTeam, err = catalogServicePrincipal(d, client, "support_team", entitlementGroupPrincipal); err != nil {
+		return err
+	}
+
+	service.Hours = nil
+	if hours := d.Get("hours").([]interface{}); len(hours) > 0 {
+		serviceHours := hours[0].(map[string]interface{})
+		service.Hours = &ServiceHours{
+			StartTime: serviceHours["start_time"].(string),
+			EndTime:   serviceHours["end_time"].(string),
+		}
+	}
+	return nil
+}
+
+//catalogServicePrincipal - To read a principal argument, nil if it is not set
+func catalogServicePrincipal(d *schema.ResourceData, client *APIClient, argument string, principalType string) (*EntitlementPrincipal, error) {
+	principal := d.Get(argument).(string)
+	if len(principal) == 0 {
+		return nil, nil
+	}
+	if _, err := parsePrincipalID(principal); err != nil {
+		return nil, fmt.Errorf("Invalid %s: %v", argument, err)
+	}
+	return &EntitlementPrincipal{TenantName: client.Tenant, Ref: principal, Type: principalType}, nil
+}
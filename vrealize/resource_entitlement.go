@@ -0,0 +1,286 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+//Entitlement statuses and principal types
+const (
+	entitlementActive   = "ACTIVE"
+	entitlementInactive = "INACTIVE"
+
+	entitlementUserPrincipal  = "USER"
+	entitlementGroupPrincipal = "GROUP"
+)
+
+//ResourceEntitlement - use to set entitlement resource fields
+func ResourceEntitlement() *schema.Resource {
+	return &schema.Resource{
+		Create: createEntitlement,
+		Read:   readEntitlement,
+		Update: updateEntitlement,
+		Delete: deleteEntitlement,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: entitlementSchema(),
+	}
+}
+
+//entitlementSchema - Users and groups are given as name@domain, approval policies by their ID
+func entitlementSchema() map[string]*schema.Schema {
+	principalSet := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Set:      schema.HashString,
+		}
+	}
+	entitledSet := func(fields map[string]*schema.Schema) *schema.Schema {
+		fields["approval_policy_id"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		}
+		return &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Resource{Schema: fields},
+		}
+	}
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"business_group_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"status": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      entitlementActive,
+			ValidateFunc: validation.StringInSlice([]string{entitlementActive, entitlementInactive}, false),
+		},
+		"all_users": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"local_scope_for_actions": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"users":  principalSet(),
+		"groups": principalSet(),
+		"entitled_services": entitledSet(map[string]*schema.Schema{
+			"service_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		}),
+		"entitled_catalog_items": entitledSet(map[string]*schema.Schema{
+			"catalog_item_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		}),
+		"entitled_actions": entitledSet(map[string]*schema.Schema{
+			"action_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"resource_type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		}),
+	}
+}
+
+//Function use - to create an entitlement of a business group
+//Terraform call - terraform apply
+func createEntitlement(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	entitlement := &Entitlement{}
+	if err := setEntitlementFields(d, client, entitlement); err != nil {
+		return err
+	}
+
+	created, err := client.CreateEntitlement(entitlement)
+	if err != nil {
+		return fmt.Errorf("Entitlement creation failed: %v", err)
+	}
+	d.SetId(created.ID)
+	log.Printf("createEntitlement->id %v\n", created.ID)
+	return readEntitlement(d, meta)
+}
+
+//Function use - To read an entitlement
+//Terraform call - terraform refresh
+func readEntitlement(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	entitlement, err := client.GetEntitlement(d.Id())
+	if isNotFound(err) {
+		log.Printf("readEntitlement->%v, removing it from state\n", err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Entitlement failed to load: %v", err)
+	}
+
+	d.Set("name", entitlement.Name)
+	d.Set("description", entitlement.Description)
+	d.Set("business_group_id", entitlement.Organization.SubtenantRef)
+	d.Set("status", entitlement.Status)
+	d.Set("all_users", entitlement.AllUsers)
+	d.Set("local_scope_for_actions", entitlement.LocalScopeForActions)
+
+	var users, groups []string
+	for _, principal := range entitlement.Principals {
+		if principal.Type == entitlementGroupPrincipal {
+			groups = append(groups, principal.Ref)
+		} else {
+			users = append(users, principal.Ref)
+		}
+	}
+	d.Set("users", users)
+	d.Set("groups", groups)
+
+	var services, catalogItems, actions []map[string]interface{}
+	for _, service := range entitlement.EntitledServices {
+		services = append(services, map[string]interface{}{
+			"service_id":         service.ServiceRef.ID,
+			"approval_policy_id": service.ApprovalPolicyID,
+		})
+	}
+	for _, catalogItem := range entitlement.EntitledCatalogItems {
+		catalogItems = append(catalogItems, map[string]interface{}{
+			"catalog_item_id":    catalogItem.CatalogItemRef.ID,
+			"approval_policy_id": catalogItem.ApprovalPolicyID,
+		})
+	}
+	for _, action := range entitlement.EntitledResourceOperations {
+		actions = append(actions, map[string]interface{}{
+			"action_id":          action.ResourceOperationRef.ID,
+			"resource_type":      action.TargetResourceTypeRef.ID,
+			"approval_policy_id": action.ApprovalPolicyID,
+		})
+	}
+	d.Set("entitled_services", services)
+	d.Set("entitled_catalog_items", catalogItems)
+	d.Set("entitled_actions", actions)
+	return nil
+}
+
+//Function use - To update an entitlement
+//Terraform call - terraform apply
+func updateEntitlement(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	//Keep the version and the fields not managed by terraform
+	entitlement, err := client.GetEntitlement(d.Id())
+	if err != nil {
+		return fmt.Errorf("Entitlement failed to load: %v", err)
+	}
+	if err := setEntitlementFields(d, client, entitlement); err != nil {
+		return err
+	}
+	if err := client.UpdateEntitlement(entitlement); err != nil {
+		return fmt.Errorf("Entitlement update failed: %v", err)
+	}
+	return readEntitlement(d, meta)
+}
+
+//Function use - To deactivate and delete an entitlement
+//Terraform call - terraform destroy
+func deleteEntitlement(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	err := client.DeleteEntitlement(d.Id())
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Entitlement deletion failed: %v", err)
+	}
+	d.SetId("")
+	return nil
+}
+
+//setEntitlementFields - To copy the configured fields into the entitlement
+func setEntitlementFields(d *schema.ResourceData, client *APIClient, entitlement *Entitlement) error {
+	entitlement.Name = d.Get("name").(string)
+	entitlement.Description = d.Get("description").(string)
+	entitlement.Organization.SubtenantRef = d.Get("business_group_id").(string)
+	entitlement.Status = d.Get("status").(string)
+	entitlement.AllUsers = d.Get("all_users").(bool)
+	entitlement.LocalScopeForActions = d.Get("local_scope_for_actions").(bool)
+
+	entitlement.Principals = nil
+	principalTypes := map[string]string{
+		"users":  entitlementUserPrincipal,
+		"groups": entitlementGroupPrincipal,
+	}
+	for argument, principalType := range principalTypes {
+		for _, principal := range d.Get(argument).(*schema.Set).List() {
+			if _, err := parsePrincipalID(principal.(string)); err != nil {
+				return fmt.Errorf("Invalid %s: %v", argument, err)
+			}
+			entitlement.Principals = append(entitlement.Principals, EntitlementPrincipal{
+				TenantName: client.Tenant,
+				Ref:        principal.(string),
+				Type:       principalType,
+			})
+		}
+	}
+
+	entitlement.EntitledServices = nil
+	for _, item := range d.Get("entitled_services").(*schema.Set).List() {
+		service := item.(map[string]interface{})
+		entitlement.EntitledServices = append(entitlement.EntitledServices, EntitledService{
+			ServiceRef:       Reference{ID: service["service_id"].(string)},
+			ApprovalPolicyID: service["approval_policy_id"].(string),
+			Active:           true,
+		})
+	}
+
+	entitlement.EntitledCatalogItems = nil
+	for _, item := range d.Get("entitled_catalog_items").(*schema.Set).List() {
+		catalogItem := item.(map[string]interface{})
+		entitlement.EntitledCatalogItems = append(entitlement.EntitledCatalogItems, EntitledCatalogItem{
+			CatalogItemRef:         Reference{ID: catalogItem["catalog_item_id"].(string)},
+			ApprovalPolicyID:       catalogItem["approval_policy_id"].(string),
+			Active:                 true,
+			CatalogItemRequestable: true,
+		})
+	}
+
+	entitlement.EntitledResourceOperations = nil
+	for _, item := range d.Get("entitled_actions").(*schema.Set).List() {
+		action := item.(map[string]interface{})
+		entitlement.EntitledResourceOperations = append(entitlement.EntitledResourceOperations, EntitledResourceOperation{
+			ResourceOperationRef:  Reference{ID: action["action_id"].(string)},
+			TargetResourceTypeRef: Reference{ID: action["resource_type"].(string)},
+			ResourceOperationType: "ACTION",
+			ApprovalPolicyID:      action["approval_policy_id"].(string),
+			Active:                true,
+		})
+	}
+	return nil
+}
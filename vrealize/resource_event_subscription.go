@@ -0,0 +1,169 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+//ResourceEventSubscription - use to set event subscription resource fields
+func ResourceEventSubscription() *schema.Resource {
+	return &schema.Resource{
+		Create: createEventSubscription,
+		Read:   readEventSubscription,
+		Update: updateEventSubscription,
+		Delete: deleteEventSubscription,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: eventSubscriptionSchema(),
+	}
+}
+
+//eventSubscriptionSchema - The workflow runs for events matching all conditions, without conditions
+//it runs for every event of the topic
+func eventSubscriptionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"event_topic_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"workflow_id": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"blocking": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			ForceNew: true,
+			Default:  false,
+		},
+		"priority": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      10,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      30,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"published": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"condition": conditionSchema(),
+	}
+}
+
+//Function use - to create an event subscription
+//Terraform call - terraform apply
+func createEventSubscription(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	subscription := &EventSubscription{}
+	setEventSubscriptionFields(d, subscription)
+
+	created, err := client.CreateEventSubscription(subscription)
+	if err != nil {
+		return fmt.Errorf("Event subscription creation failed: %v", err)
+	}
+	d.SetId(created.ID)
+	log.Printf("createEventSubscription->id %v\n", created.ID)
+	return readEventSubscription(d, meta)
+}
+
+//Function use - To read an event subscription and its conditions
+//Terraform call - terraform refresh
+func readEventSubscription(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	subscription, err := client.GetEventSubscription(d.Id())
+	if isNotFound(err) {
+		log.Printf("readEventSubscription->%v, removing it from state\n", err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Event subscription failed to load: %v", err)
+	}
+
+	d.Set("name", subscription.Name)
+	d.Set("description", subscription.Description)
+	d.Set("event_topic_id", subscription.EventTopicID)
+	d.Set("workflow_id", subscription.Definition.WorkflowID)
+	d.Set("blocking", subscription.Blocking)
+	d.Set("priority", subscription.Priority)
+	d.Set("timeout", subscription.Timeout)
+	d.Set("published", subscription.Status == eventSubscriptionPublished)
+
+	d.Set("condition", flattenConditions(subscription.Criteria))
+	return nil
+}
+
+//Function use - To update an event subscription
+//Terraform call - terraform apply
+func updateEventSubscription(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	subscription := &EventSubscription{ID: d.Id()}
+	setEventSubscriptionFields(d, subscription)
+	if err := client.UpdateEventSubscription(subscription); err != nil {
+		return fmt.Errorf("Event subscription update failed: %v", err)
+	}
+	return readEventSubscription(d, meta)
+}
+
+//Function use - To delete an event subscription
+//Terraform call - terraform destroy
+func deleteEventSubscription(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	err := client.DeleteEventSubscription(d.Id())
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Event subscription deletion failed: %v", err)
+	}
+	d.SetId("")
+	return nil
+}
+
+//setEventSubscriptionFields - To copy the configured fields and conditions into the event subscription
+func setEventSubscriptionFields(d *schema.ResourceData, subscription *EventSubscription) {
+	subscription.Name = d.Get("name").(string)
+	subscription.Description = d.Get("description").(string)
+	subscription.Type = workflowSubscriptionType
+	subscription.EventTopicID = d.Get("event_topic_id").(string)
+	subscription.Definition.WorkflowID = d.Get("workflow_id").(string)
+	subscription.Blocking = d.Get("blocking").(bool)
+	subscription.Priority = d.Get("priority").(int)
+	subscription.Timeout = d.Get("timeout").(int)
+
+	subscription.Status = eventSubscriptionDraft
+	if d.Get("published").(bool) {
+		subscription.Status = eventSubscriptionPublished
+	}
+
+	conditions := expandConditions(d.Get("condition").([]interface{}))
+	subscription.Criteria = nil
+	if len(conditions) > 0 {
+		subscription.Criteria = conditionClause(conditions)
+	}
+}
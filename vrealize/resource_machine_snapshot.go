@@ -0,0 +1,237 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+//Machine snapshot operations and the fields of their action templates
+const (
+	createSnapshotOperation = "Create Snapshot"
+	revertSnapshotOperation = "Revert To Snapshot"
+	deleteSnapshotOperation = "Delete Snapshot"
+
+	snapshotNameField        = "provider-SnapshotName"
+	snapshotDescriptionField = "provider-SnapshotDescription"
+	snapshotMemoryField      = "provider-SnapshotMemory"
+	snapshotReferenceField   = "provider-SnapshotReference"
+)
+
+//MachineSnapshot - is used to store a snapshot read from the SNAPSHOT_LIST of a machine
+type MachineSnapshot struct {
+	Reference    string
+	Name         string
+	Description  string
+	CreationDate string
+	IsCurrent    bool
+}
+
+//ResourceMachineSnapshot - use to set machine snapshot resource fields
+func ResourceMachineSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: createMachineSnapshot,
+		Read:   readMachineSnapshot,
+		Update: updateMachineSnapshot,
+		Delete: deleteMachineSnapshot,
+		Schema: machineSnapshotSchema(),
+	}
+}
+
+//machineSnapshotSchema - A snapshot can not be changed once it is taken, only reverted to
+//whenever revert_trigger changes
+func machineSnapshotSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"machine_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+		"include_memory": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			ForceNew: true,
+			Default:  false,
+		},
+		"revert_trigger": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"wait_timeout": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  15,
+		},
+		"creation_date": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"is_current": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+	}
+}
+
+//Function use - to take a snapshot of a machine
+//Terraform call - terraform apply
+func createMachineSnapshot(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+	machineID := d.Get("machine_id").(string)
+	name := d.Get("name").(string)
+
+	existing, err := client.GetMachineSnapshots(machineID)
+	if err != nil {
+		return err
+	}
+	if findMachineSnapshot(existing, "", name) != nil {
+		return fmt.Errorf("machine %s already has a snapshot named %s", machineID, name)
+	}
+
+	data := map[string]interface{}{
+		snapshotNameField:        name,
+		snapshotDescriptionField: d.Get("description").(string),
+		snapshotMemoryField:      d.Get("include_memory").(bool),
+	}
+	if err := runMachineSnapshotAction(d, client, createSnapshotOperation, data); err != nil {
+		return err
+	}
+
+	//The snapshot is identified by the reference vRA assigned to it
+	snapshots, err := client.GetMachineSnapshots(machineID)
+	if err != nil {
+		return err
+	}
+	snapshot := findMachineSnapshot(snapshots, "", name)
+	if snapshot == nil {
+		return fmt.Errorf("snapshot %s was not found on machine %s after it was created", name, machineID)
+	}
+	d.SetId(snapshot.Reference)
+	return readMachineSnapshot(d, meta)
+}
+
+//Function use - To read the snapshot from the snapshot list of the machine
+//Terraform call - terraform refresh
+func readMachineSnapshot(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	snapshots, err := client.GetMachineSnapshots(d.Get("machine_id").(string))
+	if err != nil {
+		return err
+	}
+
+	snapshot := findMachineSnapshot(snapshots, d.Id(), "")
+	if snapshot == nil {
+		log.Printf("readMachineSnapshot->snapshot %s not found, removing it from state\n", d.Id())
+		d.SetId("")
+		return nil
+	}
+	d.Set("name", snapshot.Name)
+	d.Set("description", snapshot.Description)
+	d.Set("creation_date", snapshot.CreationDate)
+	d.Set("is_current", snapshot.IsCurrent)
+	return nil
+}
+
+//Function use - To revert the machine to the snapshot when revert_trigger changes
+//Terraform call - terraform apply
+func updateMachineSnapshot(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	if d.HasChange("revert_trigger") && len(d.Get("revert_trigger").(string)) > 0 {
+		data := map[string]interface{}{snapshotReferenceField: d.Id()}
+		if err := runMachineSnapshotAction(d, client, revertSnapshotOperation, data); err != nil {
+			return err
+		}
+	}
+	return readMachineSnapshot(d, meta)
+}
+
+//Function use - To delete the snapshot
+//Terraform call - terraform destroy
+func deleteMachineSnapshot(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	snapshots, err := client.GetMachineSnapshots(d.Get("machine_id").(string))
+	if err != nil {
+		return err
+	}
+
+	//Nothing to do if the snapshot got deleted outside terraform
+	if findMachineSnapshot(snapshots, d.Id(), "") != nil {
+		data := map[string]interface{}{snapshotReferenceField: d.Id()}
+		if err := runMachineSnapshotAction(d, client, deleteSnapshotOperation, data); err != nil {
+			return err
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+//runMachineSnapshotAction - To run a snapshot operation on the machine and wait for its request
+func runMachineSnapshotAction(d *schema.ResourceData, client *APIClient, operationName string, data map[string]interface{}) error {
+	requestID, err := client.SubmitResourceAction(d.Get("machine_id").(string), operationName, "", data)
+	if err != nil {
+		return fmt.Errorf("%s failed: %v", operationName, err)
+	}
+
+	_, err = client.WaitForRequestCompletion(requestID, d.Get("wait_timeout").(int))
+	if err != nil {
+		return fmt.Errorf("%s failed: %v", operationName, err)
+	}
+	return nil
+}
+
+//findMachineSnapshot - To find a snapshot by its reference or, if no reference is given, by its name
+func findMachineSnapshot(snapshots []MachineSnapshot, reference string, name string) *MachineSnapshot {
+	for i := range snapshots {
+		if len(reference) > 0 && snapshots[i].Reference == reference ||
+			len(reference) == 0 && snapshots[i].Name == name {
+			return &snapshots[i]
+		}
+	}
+	return nil
+}
+
+//GetMachineSnapshots - To read the snapshots of a machine resource
+func (c *APIClient) GetMachineSnapshots(machineID string) ([]MachineSnapshot, error) {
+	resourceView, err := c.GetResourceView(machineID)
+	if err != nil {
+		return nil, fmt.Errorf("Resource view failed to load:  %v", err)
+	}
+	if !resourceView.isMachine() {
+		return nil, fmt.Errorf("resource %s is not a machine", machineID)
+	}
+
+	snapshotList, _ := resourceView.Data["SNAPSHOT_LIST"].([]interface{})
+	var snapshots []MachineSnapshot
+	for _, item := range snapshotList {
+		//Every snapshot is a nested model whose values are kept in its data
+		model, _ := item.(map[string]interface{})
+		data, _ := model["data"].(map[string]interface{})
+
+		snapshot := MachineSnapshot{}
+		snapshot.Reference, _ = data["SNAPSHOT_REFERENCE"].(string)
+		snapshot.Name, _ = data["SNAPSHOT_NAME"].(string)
+		snapshot.Description, _ = data["SNAPSHOT_DESCRIPTION"].(string)
+		snapshot.CreationDate, _ = data["SNAPSHOT_CREATION_DATE"].(string)
+		snapshot.IsCurrent, _ = data["SNAPSHOT_IS_CURRENT"].(bool)
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,134 @@
+package vrealize
+
+import (
+	"encoding/json"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAPIClient_GetMachineSnapshots(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost/catalog-service/api/consumer/resourceViews/51bf8bd7-8553-4b0d-b580-41ab0cfaf9a5",
+		httpmock.NewStringResponder(200, `{"@type":"CatalogResourceView","resourceId":"51bf8bd7-8553-4b0d-b580-41ab0cfaf9a5","resourceType":"Infrastructure.Virtual","status":"On","data":{"Component":"CentOS_6.3","machineId":"4fc33663-992d-49f8-af17-df7ce4831aa0","SNAPSHOT_LIST":[{"componentTypeId":"com.vmware.csp.component.iaas.proxy.provider","componentId":null,"classId":"dynamicops.api.model.SnapshotModel","typeFilter":null,"data":{"SNAPSHOT_REFERENCE":"snapshot-1020","SNAPSHOT_NAME":"base","SNAPSHOT_DESCRIPTION":"Base image","SNAPSHOT_CREATION_DATE":"2017-07-17T14:02:45.000Z","SNAPSHOT_IS_CURRENT":false}},{"componentTypeId":"com.vmware.csp.component.iaas.proxy.provider","componentId":null,"classId":"dynamicops.api.model.SnapshotModel","typeFilter":null,"data":{"SNAPSHOT_REFERENCE":"snapshot-1021","SNAPSHOT_NAME":"before-upgrade","SNAPSHOT_DESCRIPTION":"","SNAPSHOT_CREATION_DATE":"2017-07-18T09:12:01.000Z","SNAPSHOT_IS_CURRENT":true}}]},"links":[]}`))
+
+	httpmock.RegisterResponder("GET", "http://localhost/catalog-service/api/consumer/resourceViews/7a5ce4c1-97c4-4c2e-9a5f-3f1b3d1c2e6f",
+		httpmock.NewStringResponder(200, `{"@type":"CatalogResourceView","resourceId":"7a5ce4c1-97c4-4c2e-9a5f-3f1b3d1c2e6f","resourceType":"Infrastructure.Virtual","status":"On","data":{"Component":"CentOS_6.3","machineId":"9d0b8f5e-6c1a-4c8e-b7e2-2f0c4a1b3d5e"},"links":[]}`))
+
+	httpmock.RegisterResponder("GET", "http://localhost/catalog-service/api/consumer/resourceViews/b313acd6-0738-439c-b601-e3ebf9ebb49b",
+		httpmock.NewStringResponder(200, `{"@type":"CatalogResourceView","resourceId":"b313acd6-0738-439c-b601-e3ebf9ebb49b","resourceType":"composition.resource.type.deployment","data":{},"links":[]}`))
+
+	snapshots, err := client.GetMachineSnapshots("51bf8bd7-8553-4b0d-b580-41ab0cfaf9a5")
+	if err != nil {
+		t.Fatalf("Failed to get machine snapshots %v", err)
+	}
+	expected := []MachineSnapshot{
+		{Reference: "snapshot-1020", Name: "base", Description: "Base image", CreationDate: "2017-07-17T14:02:45.000Z"},
+		{Reference: "snapshot-1021", Name: "before-upgrade", CreationDate: "2017-07-18T09:12:01.000Z", IsCurrent: true},
+	}
+	if !reflect.DeepEqual(snapshots, expected) {
+		t.Errorf("Expected machine snapshots %v, got %v", expected, snapshots)
+	}
+	snapshot := findMachineSnapshot(snapshots, "", "before-upgrade")
+	if snapshot == nil || snapshot.Reference != "snapshot-1021" || !snapshot.IsCurrent {
+		t.Errorf("Unexpected machine snapshots %v", snapshots)
+	}
+	snapshot = findMachineSnapshot(snapshots, "snapshot-1020", "")
+	if snapshot == nil || snapshot.Name != "base" {
+		t.Errorf("Failed to find snapshot by reference in %v", snapshots)
+	}
+	if findMachineSnapshot(snapshots, "snapshot-9999", "") != nil {
+		t.Errorf("Found a snapshot which does not exist.")
+	}
+
+	//A machine without snapshots has no SNAPSHOT_LIST
+	snapshots, err = client.GetMachineSnapshots("7a5ce4c1-97c4-4c2e-9a5f-3f1b3d1c2e6f")
+	if err != nil || len(snapshots) != 0 {
+		t.Errorf("Expected no snapshots, got %v %v", snapshots, err)
+	}
+
+	_, err = client.GetMachineSnapshots("b313acd6-0738-439c-b601-e3ebf9ebb49b")
+	if err == nil {
+		t.Errorf("Read snapshots of a resource which is not a machine.")
+	}
+}
+
+func TestAPIClient_SubmitSnapshotActions(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	machineURL := "http://localhost/catalog-service/api/consumer/resources/51bf8bd7-8553-4b0d-b580-41ab0cfaf9a5"
+	httpmock.RegisterResponder("GET", "http://localhost/catalog-service/api/consumer/resourceViews/51bf8bd7-8553-4b0d-b580-41ab0cfaf9a5",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(200, `{"@type":"CatalogResourceView","resourceId":"51bf8bd7-8553-4b0d-b580-41ab0cfaf9a5","resourceType":"Infrastructure.Virtual","status":"On","data":{"Component":"CentOS_6.3","machineId":"4fc33663-992d-49f8-af17-df7ce4831aa0"},"links":[]}`), nil
+		})
+	httpmock.RegisterResponder("GET", machineURL+"/actions",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(200, `{"links":[],"content":[{"@type":"ConsumerResourceOperation","name":"Create Snapshot","description":"Create a snapshot of the machine","type":"ACTION","id":"e3c5a3b2-1f4d-4b8e-9c6a-0d2f1e3b4a51","bindingId":"Infrastructure.Virtual.Action.CreateSnapshot","providerTypeId":"com.vmware.csp.component.iaas.proxy.provider"},{"@type":"ConsumerResourceOperation","name":"Revert To Snapshot","description":"Revert the machine to a snapshot","type":"ACTION","id":"f0a1b2c3-d4e5-4f60-8a7b-9c0d1e2f3a52","bindingId":"Infrastructure.Virtual.Action.RevertSnapshot","providerTypeId":"com.vmware.csp.component.iaas.proxy.provider"},{"@type":"ConsumerResourceOperation","name":"Delete Snapshot","description":"Delete a snapshot of the machine","type":"ACTION","id":"0b1c2d3e-4f5a-4b6c-8d7e-8f9a0b1c2d53","bindingId":"Infrastructure.Virtual.Action.DeleteSnapshot","providerTypeId":"com.vmware.csp.component.iaas.proxy.provider"}],"metadata":{"size":100,"totalElements":3,"totalPages":1,"number":1,"offset":0}}`), nil
+		})
+
+	actions := []struct {
+		operationName string
+		actionID      string
+		template      string
+		data          map[string]interface{}
+	}{
+		{
+			createSnapshotOperation,
+			"e3c5a3b2-1f4d-4b8e-9c6a-0d2f1e3b4a51",
+			`{"provider-SnapshotName":null,"provider-SnapshotDescription":null,"provider-SnapshotMemory":false}`,
+			map[string]interface{}{
+				snapshotNameField:        "before-upgrade",
+				snapshotDescriptionField: "taken by terraform",
+				snapshotMemoryField:      true,
+			},
+		},
+		{
+			revertSnapshotOperation,
+			"f0a1b2c3-d4e5-4f60-8a7b-9c0d1e2f3a52",
+			`{"provider-SnapshotReference":null}`,
+			map[string]interface{}{snapshotReferenceField: "snapshot-1021"},
+		},
+		{
+			deleteSnapshotOperation,
+			"0b1c2d3e-4f5a-4b6c-8d7e-8f9a0b1c2d53",
+			`{"provider-SnapshotReference":null}`,
+			map[string]interface{}{snapshotReferenceField: "snapshot-1021"},
+		},
+	}
+
+	for _, action := range actions {
+		requestURL := machineURL + "/actions/" + action.actionID + "/requests"
+		httpmock.RegisterResponder("GET", requestURL+"/template",
+			httpmock.NewStringResponder(200, `{"type":"com.vmware.vcac.catalog.domain.request.CatalogResourceRequest","resourceId":"51bf8bd7-8553-4b0d-b580-41ab0cfaf9a5","actionId":"`+action.actionID+`","description":null,"data":`+action.template+`}`))
+
+		var submitted ActionTemplate
+		httpmock.RegisterResponder("POST", requestURL,
+			func(req *http.Request) (*http.Response, error) {
+				if err := json.NewDecoder(req.Body).Decode(&submitted); err != nil {
+					return nil, err
+				}
+				resp := httpmock.NewStringResponse(201, ``)
+				resp.Header.Set("Location", "http://localhost/catalog-service/api/consumer/requests/2f6c1e0a-8d3b-4a7e-9b5c-1d2e3f4a5b6c")
+				return resp, nil
+			})
+
+		requestID, err := client.SubmitResourceAction("51bf8bd7-8553-4b0d-b580-41ab0cfaf9a5", action.operationName, "", action.data)
+		if err != nil {
+			t.Errorf("Failed to submit %s: %v", action.operationName, err)
+			continue
+		}
+		if requestID != "2f6c1e0a-8d3b-4a7e-9b5c-1d2e3f4a5b6c" {
+			t.Errorf("Expected %s request 2f6c1e0a-8d3b-4a7e-9b5c-1d2e3f4a5b6c, got %v", action.operationName, requestID)
+		}
+		if submitted.ActionID != action.actionID || submitted.ResourceID != "51bf8bd7-8553-4b0d-b580-41ab0cfaf9a5" {
+			t.Errorf("%s submitted action %s on resource %s", action.operationName, submitted.ActionID, submitted.ResourceID)
+		}
+		if !reflect.DeepEqual(submitted.Data, action.data) {
+			t.Errorf("Expected %s data %v, got %v", action.operationName, action.data, submitted.Data)
+		}
+	}
+}
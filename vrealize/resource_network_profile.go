@@ -0,0 +1,250 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+//ResourceNetworkProfile - use to set network profile resource fields
+func ResourceNetworkProfile() *schema.Resource {
+	return &schema.Resource{
+		Create: createNetworkProfile,
+		Read:   readNetworkProfile,
+		Update: updateNetworkProfile,
+		Delete: deleteNetworkProfile,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: networkProfileSchema(),
+	}
+}
+
+//networkProfileSchema - NAT and routed profiles are based on an external profile
+func networkProfileSchema() map[string]*schema.Schema {
+	ipAddress := func(required bool) *schema.Schema {
+		return &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     required,
+			Optional:     !required,
+			ValidateFunc: validateIPv4Address,
+		}
+	}
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"EXTERNAL", "NAT", "ROUTED"}, false),
+		},
+		"subnet_mask":   ipAddress(true),
+		"gateway":       ipAddress(false),
+		"primary_dns":   ipAddress(false),
+		"secondary_dns": ipAddress(false),
+		"dns_suffix": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"dns_search_suffix": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"external_profile_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"nat_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"ONETOONE", "ONETOMANY"}, false),
+		},
+		"range_subnet_mask": ipAddress(false),
+		"base_ip":           ipAddress(false),
+		"ip_range": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"description": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"start_address": ipAddress(true),
+					"end_address":   ipAddress(true),
+				},
+			},
+		},
+	}
+}
+
+//validateIPv4Address - To check an argument is an IPv4 address or mask
+func validateIPv4Address(v interface{}, k string) (ws []string, errors []error) {
+	if ip := net.ParseIP(v.(string)); ip == nil || ip.To4() == nil {
+		errors = append(errors, fmt.Errorf("%q must be an IPv4 address, got %s", k, v.(string)))
+	}
+	return
+}
+
+//Function use - to create a network profile
+//Terraform call - terraform apply
+func createNetworkProfile(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	profile := &NetworkProfile{}
+	if err := setNetworkProfileFields(d, profile); err != nil {
+		return err
+	}
+
+	profileID, err := client.CreateNetworkProfile(profile)
+	if err != nil {
+		return fmt.Errorf("Network profile creation failed: %v", err)
+	}
+	d.SetId(profileID)
+	log.Printf("createNetworkProfile->id %v\n", profileID)
+	return readNetworkProfile(d, meta)
+}
+
+//Function use - To read a network profile and its IP ranges
+//Terraform call - terraform refresh
+func readNetworkProfile(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	profile, err := client.GetNetworkProfile(d.Id())
+	if isNotFound(err) {
+		log.Printf("readNetworkProfile->%v, removing it from state\n", err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Network profile failed to load: %v", err)
+	}
+
+	d.Set("name", profile.Name)
+	d.Set("description", profile.Description)
+	d.Set("type", profile.ProfileType)
+	d.Set("subnet_mask", profile.SubnetMask)
+	d.Set("gateway", profile.GatewayAddress)
+	d.Set("primary_dns", profile.PrimaryDNSAddress)
+	d.Set("secondary_dns", profile.SecondaryDNSAddress)
+	d.Set("dns_suffix", profile.DNSSuffix)
+	d.Set("dns_search_suffix", profile.DNSSearchSuffix)
+	d.Set("external_profile_id", profile.ExternalNetProfileID)
+	d.Set("nat_type", profile.NatType)
+	d.Set("range_subnet_mask", profile.RangeSubnetMask)
+	d.Set("base_ip", profile.BaseIP)
+
+	var ranges []map[string]interface{}
+	for _, ipRange := range profile.DefinedRanges {
+		ranges = append(ranges, map[string]interface{}{
+			"name":          ipRange.Name,
+			"description":   ipRange.Description,
+			"start_address": ipRange.BeginIPv4Address,
+			"end_address":   ipRange.EndIPv4Address,
+		})
+	}
+	d.Set("ip_range", ranges)
+	return nil
+}
+
+//Function use - To update a network profile and its IP ranges
+//Terraform call - terraform apply
+func updateNetworkProfile(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	existing, err := client.GetNetworkProfile(d.Id())
+	if err != nil {
+		return fmt.Errorf("Network profile failed to load: %v", err)
+	}
+
+	profile := &NetworkProfile{ID: d.Id()}
+	if err := setNetworkProfileFields(d, profile); err != nil {
+		return err
+	}
+
+	//Ranges keep their id, and so their allocated addresses, as long as they keep their name
+	for i := range profile.DefinedRanges {
+		for _, existingRange := range existing.DefinedRanges {
+			if existingRange.Name == profile.DefinedRanges[i].Name {
+				profile.DefinedRanges[i].ID = existingRange.ID
+			}
+		}
+	}
+
+	if err := client.UpdateNetworkProfile(profile); err != nil {
+		return fmt.Errorf("Network profile update failed: %v", err)
+	}
+	return readNetworkProfile(d, meta)
+}
+
+//Function use - To delete a network profile
+//Terraform call - terraform destroy
+func deleteNetworkProfile(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	err := client.DeleteNetworkProfile(d.Id())
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Network profile deletion failed: %v", err)
+	}
+	d.SetId("")
+	return nil
+}
+
+//setNetworkProfileFields - To copy the configured fields into the network profile
+//and check the fields required by its type are set
+func setNetworkProfileFields(d *schema.ResourceData, profile *NetworkProfile) error {
+	profileType := d.Get("type").(string)
+	profile.Type = networkProfileTypes[profileType]
+	profile.ProfileType = profileType
+	profile.Name = d.Get("name").(string)
+	profile.Description = d.Get("description").(string)
+	profile.SubnetMask = d.Get("subnet_mask").(string)
+	profile.GatewayAddress = d.Get("gateway").(string)
+	profile.PrimaryDNSAddress = d.Get("primary_dns").(string)
+	profile.SecondaryDNSAddress = d.Get("secondary_dns").(string)
+	profile.DNSSuffix = d.Get("dns_suffix").(string)
+	profile.DNSSearchSuffix = d.Get("dns_search_suffix").(string)
+	profile.ExternalNetProfileID = d.Get("external_profile_id").(string)
+	profile.NatType = d.Get("nat_type").(string)
+	profile.RangeSubnetMask = d.Get("range_subnet_mask").(string)
+	profile.BaseIP = d.Get("base_ip").(string)
+
+	switch {
+	case profileType != "EXTERNAL" && len(profile.ExternalNetProfileID) == 0:
+		return fmt.Errorf("external_profile_id is required for %s network profiles", profileType)
+	case profileType == "NAT" && len(profile.NatType) == 0:
+		return fmt.Errorf("nat_type is required for NAT network profiles")
+	case profileType == "ROUTED" && (len(profile.RangeSubnetMask) == 0 || len(profile.BaseIP) == 0):
+		return fmt.Errorf("range_subnet_mask and base_ip are required for ROUTED network profiles")
+	}
+
+	profile.DefinedRanges = []NetworkRange{}
+	for _, item := range d.Get("ip_range").([]interface{}) {
+		ipRange := item.(map[string]interface{})
+		profile.DefinedRanges = append(profile.DefinedRanges, NetworkRange{
+			Name:             ipRange["name"].(string),
+			Description:      ipRange["description"].(string),
+			BeginIPv4Address: ipRange["start_address"].(string),
+			EndIPv4Address:   ipRange["end_address"].(string),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,372 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+//ResourcePropertyDefinition - use to set property definition resource fields
+func ResourcePropertyDefinition() *schema.Resource {
+	return &schema.Resource{
+		Create: createPropertyDefinition,
+		Read:   readPropertyDefinition,
+		Update: updatePropertyDefinition,
+		Delete: deletePropertyDefinition,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: propertyDefinitionSchema(),
+	}
+}
+
+//propertyDefinitionSchema - A dropdown either lists static values or the values returned by
+//a vRO action given as module/action with its input parameters. The limits are strings, so a limit of 0 can be told
+//apart from no limit.
+func propertyDefinitionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"label": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"data_type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(propertyDataTypes, false),
+		},
+		"display_control": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "TEXTBOX",
+			ValidateFunc: validation.StringInSlice(propertyDisplayAdvices, false),
+		},
+		"multi_valued": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"order_index": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"mandatory": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"min_value": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validatePropertyLimit(false),
+			DiffSuppressFunc: suppressEqualPropertyLimit,
+		},
+		"max_value": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validatePropertyLimit(false),
+			DiffSuppressFunc: suppressEqualPropertyLimit,
+		},
+		"min_length": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validatePropertyLimit(true),
+			DiffSuppressFunc: suppressEqualPropertyLimit,
+		},
+		"max_length": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validatePropertyLimit(true),
+			DiffSuppressFunc: suppressEqualPropertyLimit,
+		},
+		"value_list": {
+			Type:          schema.TypeList,
+			Optional:      true,
+			ConflictsWith: []string{"value_list_action"},
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"value": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"label": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+		"value_list_action": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"value_list"},
+		},
+		"value_list_action_parameter": {
+			Type:          schema.TypeList,
+			Optional:      true,
+			ConflictsWith: []string{"value_list"},
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"value": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"bind": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+				},
+			},
+		},
+	}
+}
+
+//Function use - to create a property definition
+//Terraform call - terraform apply
+func createPropertyDefinition(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	definition := &PropertyDefinition{ID: d.Get("name").(string)}
+	if err := setPropertyDefinitionFields(d, definition); err != nil {
+		return err
+	}
+	if err := client.CreatePropertyDefinition(definition); err != nil {
+		return fmt.Errorf("Property definition creation failed: %v", err)
+	}
+	d.SetId(definition.ID)
+	log.Printf("createPropertyDefinition->id %v\n", definition.ID)
+	return readPropertyDefinition(d, meta)
+}
+
+//Function use - To read a property definition with its constraints and value list
+//Terraform call - terraform refresh
+func readPropertyDefinition(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	definition, err := client.GetPropertyDefinition(d.Id())
+	if isNotFound(err) {
+		log.Printf("readPropertyDefinition->%v, removing it from state\n", err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Property definition failed to load: %v", err)
+	}
+
+	d.Set("name", definition.ID)
+	d.Set("label", definition.Label)
+	d.Set("description", definition.Description)
+	d.Set("data_type", definition.DataType.TypeID)
+	d.Set("display_control", definition.DisplayAdvice)
+	d.Set("multi_valued", definition.IsMultiValued)
+	if definition.OrderIndex != nil {
+		d.Set("order_index", *definition.OrderIndex)
+	}
+
+	mandatory, _ := definition.facetValue(propertyMandatoryFacet).(bool)
+	d.Set("mandatory", mandatory)
+	d.Set("min_value", definition.facetLimit(propertyMinValueFacet))
+	d.Set("max_value", definition.facetLimit(propertyMaxValueFacet))
+	d.Set("min_length", definition.facetLimit(propertyMinLengthFacet))
+	d.Set("max_length", definition.facetLimit(propertyMaxLengthFacet))
+
+	var valueList []map[string]interface{}
+	var valueListAction string
+	var valueListActionParameters []map[string]interface{}
+	switch definition.ValueList["type"] {
+	case staticValueListType:
+		values, _ := definition.ValueList["values"].([]interface{})
+		for _, item := range values {
+			value, _ := item.(map[string]interface{})
+			underlyingValue, _ := value["underlyingValue"].(map[string]interface{})
+			valueList = append(valueList, map[string]interface{}{
+				"value": fmt.Sprint(underlyingValue["value"]),
+				"label": value["label"],
+			})
+		}
+	case scriptActionValueListType:
+		valueListAction, _ = definition.ValueList["id"].(string)
+		valueListActionParameters = definition.flattenActionInputParameters()
+	}
+	d.Set("value_list", valueList)
+	d.Set("value_list_action", valueListAction)
+	d.Set("value_list_action_parameter", valueListActionParameters)
+	return nil
+}
+
+//Function use - To update a property definition
+//Terraform call - terraform apply
+func updatePropertyDefinition(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	definition := &PropertyDefinition{ID: d.Id()}
+	if err := setPropertyDefinitionFields(d, definition); err != nil {
+		return err
+	}
+	if err := client.UpdatePropertyDefinition(definition); err != nil {
+		return fmt.Errorf("Property definition update failed: %v", err)
+	}
+	return readPropertyDefinition(d, meta)
+}
+
+//Function use - To delete a property definition
+//Terraform call - terraform destroy
+func deletePropertyDefinition(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	err := client.DeletePropertyDefinition(d.Id())
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Property definition deletion failed: %v", err)
+	}
+	d.SetId("")
+	return nil
+}
+
+//setPropertyDefinitionFields - To copy the configured fields into the property definition
+func setPropertyDefinitionFields(d *schema.ResourceData, definition *PropertyDefinition) error {
+	dataType := d.Get("data_type").(string)
+	definition.Label = d.Get("label").(string)
+	if len(definition.Label) == 0 {
+		definition.Label = definition.ID
+	}
+	definition.Description = d.Get("description").(string)
+	definition.DataType = PropertyDataType{Type: "primitive", TypeID: dataType}
+	definition.DisplayAdvice = d.Get("display_control").(string)
+	definition.IsMultiValued = d.Get("multi_valued").(bool)
+	if orderIndex, ok := d.GetOk("order_index"); ok {
+		index := orderIndex.(int)
+		definition.OrderIndex = &index
+	}
+
+	//Numeric constraints have the type of the property
+	numberType := "DECIMAL"
+	if dataType == "INTEGER" {
+		numberType = "INTEGER"
+	}
+	definition.Facets = map[string]interface{}{}
+	if d.Get("mandatory").(bool) {
+		definition.Facets[propertyMandatoryFacet] = constantFacet("boolean", true)
+	}
+	limitFacets := []struct {
+		field     string
+		facet     string
+		limitType string
+	}{
+		{"min_value", propertyMinValueFacet, numberType},
+		{"max_value", propertyMaxValueFacet, numberType},
+		{"min_length", propertyMinLengthFacet, "INTEGER"},
+		{"max_length", propertyMaxLengthFacet, "INTEGER"},
+	}
+	for _, limit := range limitFacets {
+		if value := d.Get(limit.field).(string); len(value) > 0 {
+			literal, err := propertyLiteral(limit.limitType, value)
+			if err != nil {
+				return fmt.Errorf("%s: %v", limit.field, err)
+			}
+			definition.Facets[limit.facet] = constantFacet(literal["type"].(string), literal["value"])
+		}
+	}
+
+	definition.ValueList = nil
+	if action, ok := d.GetOk("value_list_action"); ok {
+		inputParameters := []interface{}{}
+		for _, item := range d.Get("value_list_action_parameter").([]interface{}) {
+			parameter := item.(map[string]interface{})
+			inputParameters = append(inputParameters, actionInputParameter(
+				parameter["name"].(string), parameter["value"].(string), parameter["bind"].(bool)))
+		}
+		definition.ValueList = map[string]interface{}{
+			"type":            scriptActionValueListType,
+			"id":              action,
+			"inputParameters": inputParameters,
+		}
+	} else if items := d.Get("value_list").([]interface{}); len(items) > 0 {
+		var values []interface{}
+		for _, item := range items {
+			value := item.(map[string]interface{})
+			literal, err := propertyLiteral(dataType, value["value"].(string))
+			if err != nil {
+				return err
+			}
+			values = append(values, map[string]interface{}{
+				"underlyingValue": literal,
+				"label":           value["label"],
+			})
+		}
+		definition.ValueList = map[string]interface{}{"type": staticValueListType, "values": values}
+	}
+	return nil
+}
+
+//validatePropertyLimit - To check a limit is a number, lengths have to be non-negative integers
+func validatePropertyLimit(length bool) schema.SchemaValidateFunc {
+	return func(value interface{}, key string) ([]string, []error) {
+		limit := value.(string)
+		if !length {
+			if _, err := strconv.ParseFloat(limit, 64); err != nil {
+				return nil, []error{fmt.Errorf("%s must be a number, got %s", key, limit)}
+			}
+			return nil, nil
+		}
+		if number, err := strconv.Atoi(limit); err != nil || number < 0 {
+			return nil, []error{fmt.Errorf("%s must be a non-negative integer, got %s", key, limit)}
+		}
+		return nil, nil
+	}
+}
+
+//suppressEqualPropertyLimit - vRA reports limits as numbers, so 10 and 10.0 are the same limit
+func suppressEqualPropertyLimit(k, old, new string, d *schema.ResourceData) bool {
+	oldNumber, oldErr := strconv.ParseFloat(old, 64)
+	newNumber, newErr := strconv.ParseFloat(new, 64)
+	if oldErr != nil || newErr != nil {
+		return false
+	}
+	return oldNumber == newNumber
+}
+
+//propertyLiteral - To convert a configured value to a literal of the property data type
+func propertyLiteral(dataType string, value string) (map[string]interface{}, error) {
+	switch dataType {
+	case "INTEGER":
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("value %s is not an integer", value)
+		}
+		return literalValue("integer", number), nil
+	case "DECIMAL":
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value %s is not a decimal", value)
+		}
+		return literalValue("decimal", number), nil
+	case "BOOLEAN":
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("value %s is not a boolean", value)
+		}
+		return literalValue("boolean", flag), nil
+	}
+	return literalValue("string", value), nil
+}
@@ -0,0 +1,156 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+//ResourcePropertyGroup - use to set property group resource fields
+func ResourcePropertyGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: createPropertyGroup,
+		Read:   readPropertyGroup,
+		Update: updatePropertyGroup,
+		Delete: deletePropertyGroup,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: propertyGroupSchema(),
+	}
+}
+
+//propertyGroupSchema - Properties are given as name and value, show_in_request lists the
+//properties the requester is prompted for
+func propertyGroupSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"label": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"properties": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     schema.TypeString,
+		},
+		"show_in_request": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Set:      schema.HashString,
+		},
+	}
+}
+
+//Function use - to create a property group
+//Terraform call - terraform apply
+func createPropertyGroup(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	group := &PropertyGroup{ID: d.Get("name").(string)}
+	setPropertyGroupFields(d, group)
+	if err := client.CreatePropertyGroup(group); err != nil {
+		return fmt.Errorf("Property group creation failed: %v", err)
+	}
+	d.SetId(group.ID)
+	log.Printf("createPropertyGroup->id %v\n", group.ID)
+	return readPropertyGroup(d, meta)
+}
+
+//Function use - To read a property group and its properties
+//Terraform call - terraform refresh
+func readPropertyGroup(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	group, err := client.GetPropertyGroup(d.Id())
+	if isNotFound(err) {
+		log.Printf("readPropertyGroup->%v, removing it from state\n", err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Property group failed to load: %v", err)
+	}
+
+	properties := make(map[string]interface{})
+	var showInRequest []string
+	for _, entry := range group.Properties.Entries {
+		propertyValue, _ := entry.Value.(map[string]interface{})
+		value, _ := propertyValue["value"].(map[string]interface{})
+		properties[entry.Key] = fmt.Sprint(value["value"])
+		if visible, _ := propertyValue["visibility"].(bool); visible {
+			showInRequest = append(showInRequest, entry.Key)
+		}
+	}
+
+	d.Set("name", group.ID)
+	d.Set("label", group.Label)
+	d.Set("description", group.Description)
+	d.Set("properties", properties)
+	d.Set("show_in_request", showInRequest)
+	return nil
+}
+
+//Function use - To update a property group
+//Terraform call - terraform apply
+func updatePropertyGroup(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	group := &PropertyGroup{ID: d.Id()}
+	setPropertyGroupFields(d, group)
+	if err := client.UpdatePropertyGroup(group); err != nil {
+		return fmt.Errorf("Property group update failed: %v", err)
+	}
+	return readPropertyGroup(d, meta)
+}
+
+//Function use - To delete a property group
+//Terraform call - terraform destroy
+func deletePropertyGroup(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	err := client.DeletePropertyGroup(d.Id())
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Property group deletion failed: %v", err)
+	}
+	d.SetId("")
+	return nil
+}
+
+//setPropertyGroupFields - To copy the configured fields into the property group
+func setPropertyGroupFields(d *schema.ResourceData, group *PropertyGroup) {
+	group.Label = d.Get("label").(string)
+	if len(group.Label) == 0 {
+		group.Label = group.ID
+	}
+	group.Description = d.Get("description").(string)
+
+	showInRequest := d.Get("show_in_request").(*schema.Set)
+	group.Properties = ExtensionData{Entries: []ExtensionEntry{}}
+	for name, value := range d.Get("properties").(map[string]interface{}) {
+		group.Properties.Entries = append(group.Properties.Entries, ExtensionEntry{
+			Key: name,
+			Value: map[string]interface{}{
+				"type":       "propertyValue",
+				"value":      literalValue("string", value),
+				"encrypted":  false,
+				"visibility": showInRequest.Contains(name),
+			},
+		})
+	}
+}
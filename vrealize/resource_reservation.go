@@ -0,0 +1,291 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+//ResourceReservation - use to set vSphere reservation resource fields
+func ResourceReservation() *schema.Resource {
+	return &schema.Resource{
+		Create: createReservation,
+		Read:   readReservation,
+		Update: updateReservation,
+		Delete: deleteReservation,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: reservationSchema(),
+	}
+}
+
+//reservationSchema - The compute resource, datastores and networks are given by their names
+//as shown by vRA, they are looked up when the reservation is created or updated
+func reservationSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"business_group_id": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"reservation_policy_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"priority": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"compute_resource": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"machine_quota": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"memory_size_mb": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"storage": {
+			Type:     schema.TypeList,
+			Required: true,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"path": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"size_gb": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"priority": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      0,
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+					"enabled": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+				},
+			},
+		},
+		"network": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"path": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"network_profile_id": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+//Function use - to create a vSphere reservation
+//Terraform call - terraform apply
+func createReservation(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	computeResourceName := d.Get("compute_resource").(string)
+	computeResource, err := client.GetReservationFieldValue(reservationComputeResourceKey, computeResourceName, nil)
+	if err != nil {
+		return fmt.Errorf("Compute resource failed to load: %v", err)
+	}
+
+	reservation := &Reservation{ReservationTypeID: vSphereReservationType}
+	reservation.ExtensionData.setExtensionEntry(reservationComputeResourceKey, computeResource)
+	if err := setReservationFields(d, client, reservation); err != nil {
+		return err
+	}
+
+	reservationID, err := client.CreateReservation(reservation)
+	if err != nil {
+		return fmt.Errorf("Reservation creation failed: %v", err)
+	}
+	d.SetId(reservationID)
+	log.Printf("createReservation->id %v\n", reservationID)
+	return readReservation(d, meta)
+}
+
+//Function use - To read a vSphere reservation and its allocations
+//Terraform call - terraform refresh
+func readReservation(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	reservation, err := client.GetReservation(d.Id())
+	if isNotFound(err) {
+		log.Printf("readReservation->%v, removing it from state\n", err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Reservation failed to load: %v", err)
+	}
+	if reservation.ReservationTypeID != vSphereReservationType {
+		return fmt.Errorf("reservation %s is of type %s, only %s is supported",
+			d.Id(), reservation.ReservationTypeID, vSphereReservationType)
+	}
+
+	d.Set("name", reservation.Name)
+	d.Set("business_group_id", reservation.SubTenantID)
+	d.Set("reservation_policy_id", reservation.ReservationPolicyID)
+	d.Set("priority", reservation.Priority)
+	d.Set("enabled", reservation.Enabled)
+
+	extensionData := reservation.ExtensionData
+	d.Set("compute_resource", entityRefLabel(extensionData.extensionEntry(reservationComputeResourceKey)))
+	d.Set("machine_quota", literalInt(extensionData.extensionEntry(reservationMachineQuotaKey)))
+
+	memory := complexEntries(extensionData.extensionEntry(reservationMemoryKey))
+	d.Set("memory_size_mb", literalInt(memory.extensionEntry(reservationMemorySizeKey)))
+
+	var storages []map[string]interface{}
+	for _, item := range multipleItems(extensionData.extensionEntry(reservationStoragesKey)) {
+		storage := complexEntries(item)
+		storages = append(storages, map[string]interface{}{
+			"path":     entityRefLabel(storage.extensionEntry(reservationStoragePathKey)),
+			"size_gb":  literalInt(storage.extensionEntry(reservationStorageSizeKey)),
+			"priority": literalInt(storage.extensionEntry(reservationStoragePriorityKey)),
+			"enabled":  literalBool(storage.extensionEntry(reservationStorageEnabledKey)),
+		})
+	}
+	d.Set("storage", storages)
+
+	var networks []map[string]interface{}
+	for _, item := range multipleItems(extensionData.extensionEntry(reservationNetworksKey)) {
+		network := complexEntries(item)
+		profileID, _ := network.extensionEntry(reservationNetworkProfileKey)["id"].(string)
+		networks = append(networks, map[string]interface{}{
+			"path":               entityRefLabel(network.extensionEntry(reservationNetworkPathKey)),
+			"network_profile_id": profileID,
+		})
+	}
+	d.Set("network", networks)
+	return nil
+}
+
+//Function use - To update a vSphere reservation
+//Terraform call - terraform apply
+func updateReservation(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	//Keep the alert policy and the extension data not managed by terraform
+	reservation, err := client.GetReservation(d.Id())
+	if err != nil {
+		return fmt.Errorf("Reservation failed to load: %v", err)
+	}
+	if err := setReservationFields(d, client, reservation); err != nil {
+		return err
+	}
+	if err := client.UpdateReservation(reservation); err != nil {
+		return fmt.Errorf("Reservation update failed: %v", err)
+	}
+	return readReservation(d, meta)
+}
+
+//Function use - To delete a vSphere reservation
+//Terraform call - terraform destroy
+func deleteReservation(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	err := client.DeleteReservation(d.Id())
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Reservation deletion failed: %v", err)
+	}
+	d.SetId("")
+	return nil
+}
+
+//setReservationFields - To copy the configured fields into the reservation, looking up the
+//datastores and networks of its compute resource
+func setReservationFields(d *schema.ResourceData, client *APIClient, reservation *Reservation) error {
+	reservation.Name = d.Get("name").(string)
+	reservation.SubTenantID = d.Get("business_group_id").(string)
+	reservation.ReservationPolicyID = d.Get("reservation_policy_id").(string)
+	reservation.Priority = d.Get("priority").(int)
+	reservation.Enabled = d.Get("enabled").(bool)
+
+	extensionData := &reservation.ExtensionData
+	dependencies := []ExtensionEntry{{
+		Key:   reservationComputeResourceKey,
+		Value: extensionData.extensionEntry(reservationComputeResourceKey),
+	}}
+
+	extensionData.setExtensionEntry(reservationMachineQuotaKey,
+		literalValue("integer", d.Get("machine_quota").(int)))
+	extensionData.setExtensionEntry(reservationMemoryKey,
+		complexValue("Infrastructure.Reservation.Memory", []ExtensionEntry{
+			{Key: reservationMemorySizeKey, Value: literalValue("integer", d.Get("memory_size_mb").(int))},
+		}))
+
+	storages := []interface{}{}
+	for _, item := range d.Get("storage").([]interface{}) {
+		storage := item.(map[string]interface{})
+		storagePath, err := client.GetReservationFieldValue(reservationStoragePathKey, storage["path"].(string), dependencies)
+		if err != nil {
+			return fmt.Errorf("Storage failed to load: %v", err)
+		}
+		storages = append(storages, complexValue("Infrastructure.Reservation.Storage", []ExtensionEntry{
+			{Key: reservationStoragePathKey, Value: storagePath},
+			{Key: reservationStorageSizeKey, Value: literalValue("integer", storage["size_gb"].(int))},
+			{Key: reservationStoragePriorityKey, Value: literalValue("integer", storage["priority"].(int))},
+			{Key: reservationStorageEnabledKey, Value: literalValue("boolean", storage["enabled"].(bool))},
+		}))
+	}
+	extensionData.setExtensionEntry(reservationStoragesKey, multipleValue(storages))
+
+	networks := []interface{}{}
+	for _, item := range d.Get("network").([]interface{}) {
+		network := item.(map[string]interface{})
+		networkPath, err := client.GetReservationFieldValue(reservationNetworkPathKey, network["path"].(string), dependencies)
+		if err != nil {
+			return fmt.Errorf("Network failed to load: %v", err)
+		}
+		entries := []ExtensionEntry{{Key: reservationNetworkPathKey, Value: networkPath}}
+		if profileID := network["network_profile_id"].(string); len(profileID) > 0 {
+			entries = append(entries, ExtensionEntry{
+				Key:   reservationNetworkProfileKey,
+				Value: entityRefValue("NetworkProfile", profileID, ""),
+			})
+		}
+		networks = append(networks, complexValue("Infrastructure.Reservation.Network", entries))
+	}
+	extensionData.setExtensionEntry(reservationNetworksKey, multipleValue(networks))
+	return nil
+}
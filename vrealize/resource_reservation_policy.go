@@ -0,0 +1,124 @@
+package vrealize
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+//ResourceReservationPolicy - use to set reservation policy resource fields
+func ResourceReservationPolicy() *schema.Resource {
+	return reservationPolicyResource(computeReservationPolicyType)
+}
+
+//ResourceStorageReservationPolicy - use to set storage reservation policy resource fields
+func ResourceStorageReservationPolicy() *schema.Resource {
+	return reservationPolicyResource(storageReservationPolicyType)
+}
+
+//reservationPolicyResource - Reservation and storage reservation policies only differ by their type
+func reservationPolicyResource(policyTypeID string) *schema.Resource {
+	return &schema.Resource{
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			return createReservationPolicy(d, meta, policyTypeID)
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return readReservationPolicy(d, meta, policyTypeID)
+		},
+		Update: func(d *schema.ResourceData, meta interface{}) error {
+			return updateReservationPolicy(d, meta, policyTypeID)
+		},
+		Delete: deleteReservationPolicy,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+//Function use - to create a reservation policy
+//Terraform call - terraform apply
+func createReservationPolicy(d *schema.ResourceData, meta interface{}, policyTypeID string) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	policy := &ReservationPolicy{
+		Name:                    d.Get("name").(string),
+		Description:             d.Get("description").(string),
+		ReservationPolicyTypeID: policyTypeID,
+	}
+	policyID, err := client.CreateReservationPolicy(policy)
+	if err != nil {
+		return fmt.Errorf("Reservation policy creation failed: %v", err)
+	}
+	d.SetId(policyID)
+	log.Printf("createReservationPolicy->id %v\n", policyID)
+	return readReservationPolicy(d, meta, policyTypeID)
+}
+
+//Function use - To read a reservation policy
+//Terraform call - terraform refresh
+func readReservationPolicy(d *schema.ResourceData, meta interface{}, policyTypeID string) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	policy, err := client.GetReservationPolicy(d.Id())
+	if isNotFound(err) {
+		log.Printf("readReservationPolicy->%v, removing it from state\n", err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Reservation policy failed to load: %v", err)
+	}
+	if policy.ReservationPolicyTypeID != policyTypeID {
+		return fmt.Errorf("reservation policy %s is of type %s, expected %s",
+			d.Id(), policy.ReservationPolicyTypeID, policyTypeID)
+	}
+
+	d.Set("name", policy.Name)
+	d.Set("description", policy.Description)
+	return nil
+}
+
+//Function use - To update name and description of a reservation policy
+//Terraform call - terraform apply
+func updateReservationPolicy(d *schema.ResourceData, meta interface{}, policyTypeID string) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	policy := &ReservationPolicy{
+		ID:                      d.Id(),
+		Name:                    d.Get("name").(string),
+		Description:             d.Get("description").(string),
+		ReservationPolicyTypeID: policyTypeID,
+	}
+	if err := client.UpdateReservationPolicy(policy); err != nil {
+		return fmt.Errorf("Reservation policy update failed: %v", err)
+	}
+	return readReservationPolicy(d, meta, policyTypeID)
+}
+
+//Function use - To delete a reservation policy
+//Terraform call - terraform destroy
+func deleteReservationPolicy(d *schema.ResourceData, meta interface{}) error {
+	//Get client handle
+	client := meta.(*APIClient)
+
+	err := client.DeleteReservationPolicy(d.Id())
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Reservation policy deletion failed: %v", err)
+	}
+	d.SetId("")
+	return nil
+}
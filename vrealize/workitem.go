@@ -0,0 +1,149 @@
+package vrealize
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+//Request phases waiting for an approval
+const (
+	pendingPreApprovalPhase  = "PENDING_PRE_APPROVAL"
+	pendingPostApprovalPhase = "PENDING_POST_APPROVAL"
+)
+
+//Approval work item type, its actions and the form field holding the justification
+const (
+	approvalWorkItemType       = "com.vmware.csp.core.approval.workitem.request"
+	approveWorkItemAction      = "com.vmware.csp.core.approval.action.approve"
+	rejectWorkItemAction       = "com.vmware.csp.core.approval.action.reject"
+	workItemJustificationField = "source-source-justification"
+)
+
+//workItemActions - approval decisions and the work item actions submitting them
+var workItemActions = map[string]string{
+	"approve": approveWorkItemAction,
+	"reject":  rejectWorkItemAction,
+}
+
+//WorkItem - This struct holds a task assigned to users, e.g. the approval of a request
+type WorkItem struct {
+	ID               string             `json:"id"`
+	WorkItemNumber   int                `json:"workItemNumber"`
+	ItemID           string             `json:"itemId"`
+	ItemName         string             `json:"itemName"`
+	ItemRequestor    string             `json:"itemRequestor"`
+	CallbackEntityID string             `json:"callbackEntityId"`
+	Assignees        []WorkItemAssignee `json:"assignees"`
+	WorkItemType     Reference          `json:"workItemType"`
+}
+
+//WorkItemAssignee - This struct holds a user or group a work item is assigned to
+type WorkItemAssignee struct {
+	Principal     string `json:"principal"`
+	PrincipalType string `json:"principalType"`
+}
+
+//workItemList - This struct holds one page of work items
+type workItemList struct {
+	Content  []WorkItem `json:"content"`
+	Metadata Metadata   `json:"metadata"`
+}
+
+//isPendingApproval - To check if a request phase is waiting for an approval
+func isPendingApproval(phase string) bool {
+	return phase == pendingPreApprovalPhase || phase == pendingPostApprovalPhase
+}
+
+//GetRequestWorkItems - To read the open work items of a catalog request
+func (c *APIClient) GetRequestWorkItems(requestID string) ([]WorkItem, error) {
+	var workItems []WorkItem
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("limit", "100")
+		query.Set("$filter", fmt.Sprintf("itemId eq '%s'", requestID))
+		path := "/workitem-service/api/workitems?" + query.Encode()
+
+		template := new(workItemList)
+		apiError := new(APIError)
+		_, err := c.HTTPClient.New().Get(path).Receive(template, apiError)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !apiError.isEmpty() {
+			return nil, apiError
+		}
+
+		for _, workItem := range template.Content {
+			if workItem.ItemID == requestID {
+				workItems = append(workItems, workItem)
+			}
+		}
+		if page >= template.Metadata.TotalPages {
+			return workItems, nil
+		}
+	}
+}
+
+//GetRequestApprovers - To read the users and groups which are asked to approve a request
+func (c *APIClient) GetRequestApprovers(requestID string) ([]string, error) {
+	workItems, err := c.GetRequestWorkItems(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var approvers []string
+	seen := make(map[string]bool)
+	for _, workItem := range workItems {
+		for _, assignee := range workItem.Assignees {
+			if !seen[assignee.Principal] {
+				seen[assignee.Principal] = true
+				approvers = append(approvers, assignee.Principal)
+			}
+		}
+	}
+	return approvers, nil
+}
+
+//findPendingApprovalWorkItem - To find the approval work item of the current approval level,
+//later levels get work items with higher numbers
+func findPendingApprovalWorkItem(workItems []WorkItem) *WorkItem {
+	var pending *WorkItem
+	for i := range workItems {
+		if workItems[i].WorkItemType.ID != approvalWorkItemType {
+			continue
+		}
+		if pending == nil || workItems[i].WorkItemNumber > pending.WorkItemNumber {
+			pending = &workItems[i]
+		}
+	}
+	return pending
+}
+
+//CompleteWorkItem - To submit an action of a work item, e.g. approve, with a justification
+func (c *APIClient) CompleteWorkItem(workItemID string, actionID string, justification string) error {
+	path := fmt.Sprintf("/workitem-service/api/workitems/%s/actions/%s", workItemID, actionID)
+	body := map[string]interface{}{
+		"workItemId":       workItemID,
+		"workItemActionId": actionID,
+		"formData": ExtensionData{Entries: []ExtensionEntry{{
+			Key:   workItemJustificationField,
+			Value: literalValue("string", justification),
+		}}},
+	}
+
+	apiError := new(APIError)
+	_, err := c.HTTPClient.New().Post(path).BodyJSON(body).Receive(nil, apiError)
+
+	if err != nil {
+		return err
+	}
+
+	if !apiError.isEmpty() {
+		return apiError
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package vrealize
+
+import (
+	"gopkg.in/jarcoal/httpmock.v1"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAPIClient_GetRequestApprovers(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	//The work items of both requests are read through the same list, so each call gets a fresh response body
+	httpmock.RegisterResponder("GET", "http://localhost/workitem-service/api/workitems",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(200, `{"links":[],"content":[{"id":"7c9e6679-7425-40de-944b-e07fc1f90ae7","workItemNumber":12,"itemId":"937099db-5174-4862-99a3-9c2666bfca28","itemName":"CentOS 6.3","itemRequestor":"jason@corp.local","callbackEntityId":"a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d","assignees":[{"principal":"content-managers@corp.local","principalType":"GROUP"},{"principal":"jason@corp.local","principalType":"USER"}],"workItemType":{"id":"com.vmware.csp.core.approval.workitem.request","label":"Approval"}},{"id":"8d0f7780-8536-41ef-a55c-f18ad2a01bf8","workItemNumber":13,"itemId":"937099db-5174-4862-99a3-9c2666bfca28","itemName":"CentOS 6.3","itemRequestor":"jason@corp.local","callbackEntityId":"b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e","assignees":[{"principal":"jason@corp.local","principalType":"USER"}],"workItemType":{"id":"com.vmware.csp.core.approval.workitem.request","label":"Approval"}}],"metadata":{"size":100,"totalElements":2,"totalPages":1,"number":1,"offset":0}}`), nil
+		})
+
+	approvers, err := client.GetRequestApprovers("937099db-5174-4862-99a3-9c2666bfca28")
+	if err != nil {
+		t.Fatalf("Failed to get approvers %v", err)
+	}
+	expected := []string{"content-managers@corp.local", "jason@corp.local"}
+	if !reflect.DeepEqual(approvers, expected) {
+		t.Errorf("Expected approvers %v, got %v", expected, approvers)
+	}
+
+	approvers, err = client.GetRequestApprovers("0f8fad5b-d9cb-469f-a165-70867728950e")
+	if err != nil || len(approvers) != 0 {
+		t.Errorf("Expected no approvers for a request without work items, got %v %v", approvers, err)
+	}
+}
+
+func TestFindPendingApprovalWorkItem(t *testing.T) {
+	workItems := []WorkItem{
+		{ID: "7c9e6679-7425-40de-944b-e07fc1f90ae7", WorkItemNumber: 12, WorkItemType: Reference{ID: approvalWorkItemType}},
+		{ID: "8d0f7780-8536-41ef-a55c-f18ad2a01bf8", WorkItemNumber: 14, WorkItemType: Reference{ID: approvalWorkItemType}},
+		{ID: "9e1f8891-9647-42f0-b66d-0a9be3b12c09", WorkItemNumber: 15, WorkItemType: Reference{ID: "com.vmware.csp.iaas.blueprint.service.machine.workitem.reconfigure"}},
+	}
+
+	workItem := findPendingApprovalWorkItem(workItems)
+	if workItem == nil || workItem.WorkItemNumber != 14 {
+		t.Errorf("Expected work item 14 of the current approval level, got %v", workItem)
+	}
+	if findPendingApprovalWorkItem(workItems[2:]) != nil {
+		t.Errorf("Found an approval work item in a list without approvals.")
+	}
+}